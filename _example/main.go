@@ -1,7 +1,6 @@
 package main
 
 import (
-	errors2 "errors"
 	"github.com/GabrielHCataldo/go-errors/errors"
 	"github.com/GabrielHCataldo/go-helper/helper"
 	"github.com/GabrielHCataldo/go-logger/logger"
@@ -17,6 +16,7 @@ func main() {
 	errors.Details(err).PrintCause()
 	errors.Details(err).PrintStackTrace()
 	is()
+	wrap()
 	nilErr()
 	details(err)
 }
@@ -43,12 +43,21 @@ func nilErr() {
 }
 
 func is() {
-	err2 := errors2.New("test")
+	err2 := errors.Join(errors.ErrUnsupported)
 	err := errors.New("error by message with any value", 2)
 	target := errors.New("test")
 	logger.Info("errors is:", errors.Is(err, target))
-	logger.Info("errors2 is:", errors.Is(err2, target))
+	logger.Info("errors is message:", errors.IsMessage(err, target))
+	logger.Info("errors join is:", errors.Is(err2, errors.ErrUnsupported))
 	logger.Info("errors is not:", errors.IsNot(err, target))
 	logger.Info("is error detail?", errors.IsErrorDetail(err))
 	logger.Info("is error detail?", errors.IsErrorDetail(err2))
 }
+
+func wrap() {
+	err := errors.Wrap(errors.ErrUnsupported, "error wrapped with any value", 2)
+	logger.Info("wrap err:", err)
+	logger.Info("wrap err unwrap:", errors.Unwrap(err))
+	var errDetail *errors.ErrorDetail
+	logger.Info("wrap err as error detail:", errors.As(err, &errDetail))
+}
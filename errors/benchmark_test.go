@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = New("test error detail")
+	}
+}
+
+func BenchmarkIsErrorDetail(b *testing.B) {
+	err := fmt.Errorf("wrapped: %w", New("test error detail"))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = IsErrorDetail(err)
+	}
+}
+
+func BenchmarkIsErrorDetailText(b *testing.B) {
+	err := errors.New(New("test error detail").Error())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = IsErrorDetail(err)
+	}
+}
+
+func BenchmarkIsErrorDetailLegacyRegex(b *testing.B) {
+	errDetail := New("test error detail").(*ErrorDetail)
+	text := fmt.Sprint("[CAUSE]: (", errDetail.file, ":", errDetail.line, ") ", errDetail.funcName, ": ",
+		errDetail.message, " [STACK]: ", errDetail.GetDebugStack())
+	err := errors.New(text)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = regexErrorDetail.MatchString(err.Error())
+	}
+}
+
+func BenchmarkDetails(b *testing.B) {
+	err := fmt.Errorf("wrapped: %w", New("test error detail"))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Details(err)
+	}
+}
+
+func BenchmarkDetailsText(b *testing.B) {
+	err := errors.New(New("test error detail").Error())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Details(err)
+	}
+}
+
+func BenchmarkIsCode(b *testing.B) {
+	err := Wrap(fmt.Errorf("wrapped: %w", NewCode("TEST_CODE", "test error detail")), "test wrap")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = IsCode(err, "TEST_CODE")
+	}
+}
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"errors"
+	"github.com/GabrielHCataldo/go-helper/helper"
+)
+
+// Code is a machine-readable code classifying an error, for example "USER_NOT_FOUND", carried by an ErrorDetail
+// created with NewCode, NewCodef or WrapCode.
+type Code string
+
+// codeTarget is the target used by IsCode to match the code of every ErrorDetail in a chain through errors.Is.
+type codeTarget Code
+
+// NewCode is a function that creates a new error with additional error details, classified by the given code.
+// It takes in the code and variadic arguments `args` of any type and builds a message using `buildMessage`, the same
+// way New does.
+// Example usage:
+//
+//	const CodeUserNotFound Code = "USER_NOT_FOUND"
+//	err := NewCode(CodeUserNotFound, "user", 10, "not found")
+//	fmt.Println(Details(err).GetCode()) // Output: USER_NOT_FOUND
+func NewCode(code Code, args ...any) error {
+	errDetail := newDetail(1, nil, buildMessage(args...), args)
+	errDetail.code = code
+	return errDetail
+}
+
+// NewCodef is a function that creates a new error with additional error details, classified by the given code.
+// It takes in the code, a format string and variadic arguments `args` of any type and builds a message using
+// `buildMessageByFormat`, the same way Newf does.
+// Example usage:
+//
+//	err := NewCodef(CodeUserNotFound, "user %d not found", 10)
+//	fmt.Println(Details(err).GetCode()) // Output: USER_NOT_FOUND
+func NewCodef(code Code, format string, args ...any) error {
+	errDetail := newDetailf(1, nil, buildMessageByFormat(format, args...), args)
+	errDetail.code = code
+	return errDetail
+}
+
+// WrapCode is a function that creates a new error with additional error details, classified by the given code,
+// keeping `err` as its cause, the same way Wrap does.
+// If `err` is nil, it returns nil.
+// Example usage:
+//
+//	err := WrapCode(sql.ErrNoRows, CodeUserNotFound, "find user")
+//	fmt.Println(IsCode(err, CodeUserNotFound), Is(err, sql.ErrNoRows)) // Output: true true
+func WrapCode(err error, code Code, args ...any) error {
+	if helper.IsNil(err) {
+		return nil
+	}
+	errDetail := newDetail(1, err, buildMessage(args...), args)
+	errDetail.code = code
+	return errDetail
+}
+
+// IsCode is a function that reports whether any ErrorDetail in `err`'s chain is classified by the given code.
+// The chain is walked the same way Is does, including the errors joined by Join.
+// Example usage:
+//
+//	err := Wrap(NewCode(CodeUserNotFound, "user not found"), "handle request")
+//	fmt.Println(IsCode(err, CodeUserNotFound)) // Output: true
+func IsCode(err error, code Code) bool {
+	return code != "" && errors.Is(err, codeTarget(code))
+}
+
+// GetCode is a method of the ErrorDetail struct that returns the code classifying the error.
+// If the ErrorDetail has no code, it walks the chain of wrapped causes and returns the code of the nearest
+// ErrorDetail that has one, or an empty code if there is none.
+// Example usage:
+//
+//	err := Wrap(NewCode(CodeUserNotFound, "user not found"), "handle request")
+//	fmt.Println(Details(err).GetCode()) // Output: USER_NOT_FOUND
+func (e *ErrorDetail) GetCode() Code {
+	if e.code != "" || helper.IsNil(e.cause) {
+		return e.code
+	}
+	var causeDetail *ErrorDetail
+	if errors.As(e.cause, &causeDetail) {
+		return causeDetail.GetCode()
+	}
+	return ""
+}
+
+// Error is a method of the codeTarget type that implements the error interface.
+func (c codeTarget) Error() string {
+	return string(c)
+}
@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"testing"
+)
+
+const testCode Code = "TEST_CODE"
+
+func TestNewCode(t *testing.T) {
+	err := NewCode(testCode, "test error detail", 1)
+	logger.Info("err:", err)
+	logger.Info("err code:", Details(err).GetCode())
+}
+
+func TestNewCodef(t *testing.T) {
+	err := NewCodef(testCode, "%s %v", "test error detail", 1)
+	logger.Info("err:", err)
+	logger.Info("err code:", Details(err).GetCode())
+}
+
+func TestWrapCode(t *testing.T) {
+	err := WrapCode(errors.New("sub error"), testCode, "test error detail")
+	logger.Info("err:", err)
+	logger.Info("err:", WrapCode(nil, testCode, "test error detail"))
+	data, _ := json.Marshal(err)
+	logger.Info("err json:", string(data))
+	parsed, parseErr := Parse(textOf(err))
+	logger.Info("err parsed code:", parsed.GetCode(), parseErr)
+}
+
+func TestIsCode(t *testing.T) {
+	err := fmt.Errorf("test: %w", Wrap(Join(New("test"), NewCode(testCode, "test error detail")), "test wrap"))
+	if !IsCode(err, testCode) {
+		t.Error("code was not found in the chain")
+	}
+	logger.Info("errors is code:", IsCode(err, "OTHER_CODE"))
+	logger.Info("errors is code:", IsCode(err, ""))
+	logger.Info("errors is code:", IsCode(nil, testCode))
+	if Is(NewCode(testCode, "test error detail"), NewCode(testCode, "other message")) {
+		t.Error("errors with the same code should not match by Is")
+	}
+}
+
+func TestErrorGetCode(t *testing.T) {
+	err := Wrap(fmt.Errorf("test: %w", NewCode(testCode, "test error detail")), "test wrap")
+	logger.Info("err code:", Details(err).GetCode())
+	logger.Info("err code:", Details(WrapCode(err, "OTHER_CODE")).GetCode())
+	logger.Info("err code:", Details(New("test")).GetCode())
+}
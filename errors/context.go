@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"context"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"sync"
+)
+
+// The field keys recommended for the correlation identifiers extracted from a context, so the logs and the JSON of
+// errors created by different packages use the same names.
+const (
+	FieldRequestID = "request_id"
+	FieldTraceID   = "trace_id"
+	FieldSpanID    = "span_id"
+	FieldTenant    = "tenant"
+)
+
+// ContextExtractor is a function that extracts a value from a context, such as a request ID or a trace ID, returning
+// false if the context does not have it.
+type ContextExtractor func(ctx context.Context) (any, bool)
+
+// contextExtractors are the ContextExtractor registered by RegisterContextExtractor, by field key.
+var contextExtractors = map[string]ContextExtractor{}
+
+// contextExtractorsMutex guards contextExtractors.
+var contextExtractorsMutex sync.RWMutex
+
+// RegisterContextExtractor is a function that registers the given ContextExtractor, whose values are recorded with
+// the given field key on the errors created by NewCtx and WrapCtx. A previous extractor with the same key is
+// replaced, and a nil extractor removes it.
+// Example usage:
+//
+//	RegisterContextExtractor(FieldRequestID, ContextValueExtractor(middleware.RequestIDKey))
+//	RegisterContextExtractor(FieldTraceID, func(ctx context.Context) (any, bool) {
+//		spanContext := trace.SpanContextFromContext(ctx)
+//		return spanContext.TraceID().String(), spanContext.HasTraceID()
+//	})
+func RegisterContextExtractor(key string, extractor ContextExtractor) {
+	contextExtractorsMutex.Lock()
+	defer contextExtractorsMutex.Unlock()
+	if extractor == nil {
+		delete(contextExtractors, key)
+		return
+	}
+	contextExtractors[key] = extractor
+}
+
+// ContextValueExtractor is a function that returns a ContextExtractor of the value stored in the context with the
+// given key, by context.WithValue. The extractor returns false if the value is nil.
+func ContextValueExtractor(key any) ContextExtractor {
+	return func(ctx context.Context) (any, bool) {
+		value := ctx.Value(key)
+		return value, value != nil
+	}
+}
+
+// NewCtx is a function that creates a new error with additional error details, the same way New does, and records
+// the values extracted from `ctx` by the registered ContextExtractor as fields of the error, so the logs and the JSON
+// of the error carry the correlation identifiers of the request that produced it.
+// Example usage:
+//
+//	RegisterContextExtractor(FieldRequestID, ContextValueExtractor(requestIDKey))
+//	err := NewCtx(ctx, "user not found")
+//	fmt.Println(Details(err).GetFields()) // Output: map[request_id:f3a9c1]
+func NewCtx(ctx context.Context, args ...any) error {
+	errDetail := newDetail(1, nil, buildMessage(args...), args)
+	errDetail.fields = contextFields(ctx)
+	return errDetail
+}
+
+// WrapCtx is a function that creates a new error with additional error details, keeping `err` as its cause, the
+// same way Wrap does, and records the values extracted from `ctx` by the registered ContextExtractor as fields of the
+// error.
+// If `err` is nil, it returns nil.
+// Example usage:
+//
+//	user, err := repository.FindUser(ctx, id)
+//	if err != nil {
+//		return WrapCtx(ctx, err, "find user")
+//	}
+func WrapCtx(ctx context.Context, err error, args ...any) error {
+	if helper.IsNil(err) {
+		return nil
+	}
+	errDetail := newDetail(1, err, buildMessage(args...), args)
+	errDetail.fields = contextFields(ctx)
+	return errDetail
+}
+
+// contextFields is a function that returns the values extracted from the given context by the registered
+// ContextExtractor, by field key, or nil if there are none.
+func contextFields(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	contextExtractorsMutex.RLock()
+	defer contextExtractorsMutex.RUnlock()
+	var fields map[string]any
+	for key, extractor := range contextExtractors {
+		value, ok := extractor(ctx)
+		if !ok {
+			continue
+		}
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		fields[key] = redactValue(value)
+	}
+	return fields
+}
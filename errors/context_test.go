@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"testing"
+)
+
+type testContextKey struct{}
+
+func TestNewCtx(t *testing.T) {
+	RegisterContextExtractor(FieldRequestID, ContextValueExtractor(testContextKey{}))
+	defer RegisterContextExtractor(FieldRequestID, nil)
+	ctx := context.WithValue(context.TODO(), testContextKey{}, "test-request")
+	err := NewCtx(ctx, "test error detail")
+	logger.Info(fmt.Sprintf("err: %+v", err))
+	if Details(err).GetFields()[FieldRequestID] != "test-request" {
+		t.Error("request id should be recorded:", Details(err).GetFields())
+	}
+	if len(Details(NewCtx(context.TODO(), "test")).GetFields()) != 0 {
+		t.Error("missing context values should not be recorded")
+	}
+	logger.Info("err nil context:", NewCtx(nil, "test error detail"))
+}
+
+func TestWrapCtx(t *testing.T) {
+	RegisterContextExtractor(FieldTenant, func(ctx context.Context) (any, bool) {
+		return "test-tenant", true
+	})
+	defer RegisterContextExtractor(FieldTenant, nil)
+	err := WrapCtx(context.TODO(), errors.New("sub error"), "test error detail")
+	logger.Info("err:", err)
+	if Details(err).GetFields()[FieldTenant] != "test-tenant" {
+		t.Error("tenant should be recorded:", Details(err).GetFields())
+	}
+	if WrapCtx(context.TODO(), nil, "test") != nil {
+		t.Error("wrap of nil should be nil")
+	}
+}
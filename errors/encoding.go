@@ -0,0 +1,466 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"strconv"
+	"strings"
+)
+
+// The text of an ErrorDetail, returned by Error and MarshalText, follows the versioned format below, where each
+// segment between square brackets after the message is optional and present only when the field is not empty, all
+// in a single line, broken below only for readability:
+//
+//	[CAUSE v1]: (<file>:<line>) <function>: <message> [PUBLIC]: <public message> [CODE]: <code> [FIELDS]: <fields>
+//	[STACK]: <stack> [WRAPS]: <cause>
+//
+// The <line> is written as 0 when it is unknown, such as for the zero value of ErrorDetail.
+//
+// The <fields> are the fields attached by With to the ErrorDetail, encoded as a JSON object, so after parsing, their
+// values have the types decoded by the encoding/json package, such as float64 for numbers.
+//
+// The <cause> is the text of the wrapped error, in the same format when it is an ErrorDetail, or in the format
+// "[ERROR]: <text>" otherwise, followed by " [WRAPS]: <cause>" when an ErrorDetail is nested under that error, such as
+// in fmt.Errorf("context: %w", err), so the whole chain is encoded.
+//
+// In every field, the characters "\" and "[" are escaped as "\\" and "\[", and in the file and function fields the
+// character ":" is also escaped as "\:". Any other character, including newlines, is written as is, so an unescaped
+// "[" always starts a segment and every field can be parsed back exactly.
+//
+// Texts in the legacy format "[CAUSE]: (<file>:<line>) <function>: <message> [STACK]: <stack>", produced by previous
+// versions, are still recognized by Details and IsErrorDetail, but their fields can't be recovered exactly.
+const (
+	textHeader      = "[CAUSE v1]: "
+	textPublicTag   = "PUBLIC"
+	textCodeTag     = "CODE"
+	textFieldsTag   = "FIELDS"
+	textStackTag    = "STACK"
+	textWrapsTag    = "WRAPS"
+	textPlainHeader = "[ERROR]: "
+)
+
+// ErrInvalidText is returned, wrapped with a description of the problem, when parsing a text that does not follow the
+// format of an ErrorDetail.
+var ErrInvalidText = errors.New("invalid error detail text")
+
+// textEscaper escapes the message, stack and plain error text fields.
+var textEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`)
+
+// textLocationEscaper escapes the file and function fields.
+var textLocationEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `:`, `\:`)
+
+// MarshalText is a method of the ErrorDetail struct that implements the encoding.TextMarshaler interface.
+// It returns the text of the ErrorDetail and of its whole cause chain, in the versioned format documented in this
+// file, which can be parsed back with Parse or UnmarshalText without losing any field.
+// Example usage:
+//
+//	text, _ := Details(New("test error detail")).MarshalText()
+//	fmt.Println(string(text)) // Output: [CAUSE v1]: (file:line) function: test error detail [STACK]: stack trace
+func (e *ErrorDetail) MarshalText() ([]byte, error) {
+	return []byte(e.encodeText()), nil
+}
+
+// UnmarshalText is a method of the ErrorDetail struct that implements the encoding.TextUnmarshaler interface.
+// It parses the given text, in the versioned format documented in this file, into the ErrorDetail.
+// Every field of the previous value of the ErrorDetail, including its stack trace, is replaced.
+// It returns an error wrapping ErrInvalidText if the text is malformed, in this case the ErrorDetail is not changed.
+func (e *ErrorDetail) UnmarshalText(text []byte) error {
+	errDetail, err := Parse(string(text))
+	if helper.IsNotNil(err) {
+		return err
+	}
+	e.replaceWith(errDetail)
+	return nil
+}
+
+// replaceWith is a method of the ErrorDetail struct that replaces the whole content of the ErrorDetail with the
+// fields of the given decoded ErrorDetail, so nothing of the previous value, such as its program counters or its
+// resolved frames, is kept.
+func (e *ErrorDetail) replaceWith(errDetail *ErrorDetail) {
+	*e = ErrorDetail{
+		file:          errDetail.file,
+		line:          errDetail.line,
+		funcName:      errDetail.funcName,
+		message:       errDetail.message,
+		publicMessage: errDetail.publicMessage,
+		debugStack:    errDetail.debugStack,
+		frames:        errDetail.frames,
+		cause:         errDetail.cause,
+		code:          errDetail.code,
+		fields:        errDetail.fields,
+	}
+}
+
+// Parse is a function that parses the text of an ErrorDetail, as returned by Error or MarshalText, into a new
+// ErrorDetail, recovering every field and the whole cause chain.
+// It returns an error wrapping ErrInvalidText, describing the problem and its position, if the text is malformed.
+// Example usage:
+//
+//	err := New("test error detail\nwith two lines")
+//	errDetail, _ := Parse(err.Error())
+//	fmt.Println(errDetail.GetMessage()) // Output: test error detail
+//	// with two lines
+func Parse(text string) (*ErrorDetail, error) {
+	decoder := &textDecoder{text: text}
+	errDetail, err := decoder.decodeDetail()
+	if helper.IsNotNil(err) {
+		return nil, err
+	}
+	if !decoder.done() {
+		return nil, decoder.errorf("unexpected trailing text")
+	}
+	return errDetail, nil
+}
+
+// encodeText is a method of the ErrorDetail struct that builds the text of the ErrorDetail and of its whole cause
+// chain, in the versioned format documented in this file.
+func (e *ErrorDetail) encodeText() string {
+	var builder strings.Builder
+	e.writeText(&builder)
+	return builder.String()
+}
+
+// writeText is a method of the ErrorDetail struct that writes the text of the ErrorDetail and of its whole cause
+// chain to the given builder.
+func (e *ErrorDetail) writeText(builder *strings.Builder) {
+	builder.WriteString(textHeader)
+	builder.WriteString("(")
+	builder.WriteString(textLocationEscaper.Replace(e.file))
+	builder.WriteString(":")
+	if len(e.line) != 0 {
+		builder.WriteString(e.line)
+	} else {
+		builder.WriteString("0")
+	}
+	builder.WriteString(") ")
+	builder.WriteString(textLocationEscaper.Replace(e.funcName))
+	builder.WriteString(": ")
+	builder.WriteString(textEscaper.Replace(e.message))
+	if len(e.publicMessage) != 0 {
+		writeTextSegment(builder, textPublicTag)
+		builder.WriteString(textEscaper.Replace(e.publicMessage))
+	}
+	if len(e.code) != 0 {
+		writeTextSegment(builder, textCodeTag)
+		builder.WriteString(textEscaper.Replace(string(e.code)))
+	}
+	if fields := encodableFields(e.fields); len(fields) != 0 {
+		data, _ := json.Marshal(fields)
+		writeTextSegment(builder, textFieldsTag)
+		builder.WriteString(textEscaper.Replace(string(data)))
+	}
+	if debugStack := e.GetDebugStack(); len(debugStack) != 0 {
+		writeTextSegment(builder, textStackTag)
+		builder.WriteString(textEscaper.Replace(debugStack))
+	}
+	if helper.IsNil(e.cause) {
+		return
+	}
+	writeTextSegment(builder, textWrapsTag)
+	writeCauseText(builder, e.cause)
+}
+
+// writeCauseText is a function that writes the text of a wrapped cause and of its whole chain to the given builder.
+// A cause that is not an ErrorDetail is written as a plain error text, followed by the text of the error it wraps
+// when there is an ErrorDetail in the rest of its chain, see unwrapLayer.
+func writeCauseText(builder *strings.Builder, cause error) {
+	for {
+		if causeDetail, ok := cause.(*ErrorDetail); ok {
+			causeDetail.writeText(builder)
+			return
+		}
+		builder.WriteString(textPlainHeader)
+		next := unwrapLayer(cause)
+		if next == nil {
+			builder.WriteString(textEscaper.Replace(redactText(cause.Error())))
+			return
+		}
+		builder.WriteString(textEscaper.Replace(errorMessage(cause)))
+		writeTextSegment(builder, textWrapsTag)
+		cause = next
+	}
+}
+
+// writeTextSegment is a function that writes the start of an optional segment with the given tag to the builder.
+func writeTextSegment(builder *strings.Builder, tag string) {
+	builder.WriteString(" [")
+	builder.WriteString(tag)
+	builder.WriteString("]: ")
+}
+
+// textError is an error decoded from the text of a wrapped error that is not an ErrorDetail, such as one created by
+// fmt.Errorf, keeping the error it wraps, so the ErrorDetail nested under it are still reachable by Unwrap.
+type textError struct {
+	text  string
+	cause error
+}
+
+// Error is a method of the textError struct that returns the decoded text of the error.
+func (e *textError) Error() string {
+	return e.text
+}
+
+// Unwrap is a method of the textError struct that returns the decoded error wrapped by the error.
+func (e *textError) Unwrap() error {
+	return e.cause
+}
+
+// textDecoder is a parser of the text format of an ErrorDetail, keeping the position reached in the text.
+type textDecoder struct {
+	text string
+	pos  int
+}
+
+// decodeDetail is a method of the textDecoder struct that parses an ErrorDetail, and its whole cause chain, starting
+// at the current position.
+func (d *textDecoder) decodeDetail() (*ErrorDetail, error) {
+	if err := d.expect(textHeader + "("); helper.IsNotNil(err) {
+		return nil, err
+	}
+	file, err := d.readField(":", true)
+	if helper.IsNotNil(err) {
+		return nil, err
+	}
+	if err = d.expect(":"); helper.IsNotNil(err) {
+		return nil, err
+	}
+	line := d.readDigits()
+	if line == "" {
+		return nil, d.errorf("expected line number")
+	}
+	if err = d.expect(") "); helper.IsNotNil(err) {
+		return nil, err
+	}
+	funcName, err := d.readField(":", true)
+	if helper.IsNotNil(err) {
+		return nil, err
+	}
+	if err = d.expect(": "); helper.IsNotNil(err) {
+		return nil, err
+	}
+	message, err := d.readSegmentValue()
+	if helper.IsNotNil(err) {
+		return nil, err
+	}
+	errDetail := &ErrorDetail{
+		file:     file,
+		line:     line,
+		funcName: funcName,
+		message:  message,
+	}
+	seen := map[string]bool{}
+	for !d.done() {
+		tag, err := d.readSegmentTag()
+		if helper.IsNotNil(err) {
+			return nil, err
+		}
+		if seen[tag] {
+			return nil, d.errorf("duplicated segment [%s]", tag)
+		}
+		seen[tag] = true
+		switch tag {
+		case textPublicTag:
+			publicMessage, err := d.readSegmentValue()
+			if helper.IsNotNil(err) {
+				return nil, err
+			}
+			errDetail.publicMessage = publicMessage
+		case textCodeTag:
+			code, err := d.readSegmentValue()
+			if helper.IsNotNil(err) {
+				return nil, err
+			}
+			errDetail.code = Code(code)
+		case textFieldsTag:
+			data, err := d.readSegmentValue()
+			if helper.IsNotNil(err) {
+				return nil, err
+			}
+			if err = json.Unmarshal([]byte(data), &errDetail.fields); helper.IsNotNil(err) {
+				return nil, d.errorf("invalid fields: %v", err)
+			}
+		case textStackTag:
+			debugStack, err := d.readSegmentValue()
+			if helper.IsNotNil(err) {
+				return nil, err
+			}
+			errDetail.debugStack = debugStack
+			errDetail.frames = parseFrames(debugStack)
+		case textWrapsTag:
+			cause, err := d.decodeCause()
+			if helper.IsNotNil(err) {
+				return nil, err
+			}
+			errDetail.cause = cause
+			return errDetail, nil
+		default:
+			return nil, d.errorf("unknown segment [%s]", tag)
+		}
+	}
+	return errDetail, nil
+}
+
+// decodeCause is a method of the textDecoder struct that parses the wrapped cause, which is either an ErrorDetail or
+// a plain error text, optionally followed by the error it wraps, starting at the current position.
+func (d *textDecoder) decodeCause() (error, error) {
+	if !strings.HasPrefix(d.text[d.pos:], textPlainHeader) {
+		errDetail, err := d.decodeDetail()
+		if helper.IsNotNil(err) {
+			return nil, err
+		}
+		return errDetail, nil
+	}
+	d.pos += len(textPlainHeader)
+	text, err := d.readSegmentValue()
+	if helper.IsNotNil(err) || d.done() {
+		return errors.New(text), err
+	}
+	if tag, err := d.readSegmentTag(); helper.IsNotNil(err) {
+		return nil, err
+	} else if tag != textWrapsTag {
+		return nil, d.errorf("unexpected segment [%s] in plain error text", tag)
+	}
+	cause, err := d.decodeCause()
+	if helper.IsNotNil(err) {
+		return nil, err
+	}
+	return &textError{text: text, cause: cause}, nil
+}
+
+// readSegmentValue is a method of the textDecoder struct that reads the value of a field followed by optional
+// segments, removing the space that separates the value from the next segment.
+func (d *textDecoder) readSegmentValue() (string, error) {
+	value, err := d.readField("[", false)
+	if helper.IsNotNil(err) || d.done() {
+		return value, err
+	}
+	if !strings.HasSuffix(value, " ") {
+		return "", d.errorf("expected space before segment")
+	}
+	return value[:len(value)-1], nil
+}
+
+// readSegmentTag is a method of the textDecoder struct that reads the start of a segment, in the format "[TAG]: ",
+// returning the tag.
+func (d *textDecoder) readSegmentTag() (string, error) {
+	if err := d.expect("["); helper.IsNotNil(err) {
+		return "", err
+	}
+	end := strings.Index(d.text[d.pos:], "]: ")
+	if end < 0 {
+		return "", d.errorf("unterminated segment tag")
+	}
+	tag := d.text[d.pos : d.pos+end]
+	d.pos += end + len("]: ")
+	return tag, nil
+}
+
+// readField is a method of the textDecoder struct that reads and unescapes a field until one of the unescaped stop
+// characters or the end of the text is reached. The character ":" is accepted as escaped only if `location` is true.
+func (d *textDecoder) readField(stops string, location bool) (string, error) {
+	var builder strings.Builder
+	for d.pos < len(d.text) {
+		c := d.text[d.pos]
+		if c == '\\' {
+			if d.pos+1 >= len(d.text) {
+				return "", d.errorf("unterminated escape sequence")
+			}
+			escaped := d.text[d.pos+1]
+			if escaped != '\\' && escaped != '[' && (escaped != ':' || !location) {
+				return "", d.errorf("invalid escape sequence %q", d.text[d.pos:d.pos+2])
+			}
+			builder.WriteByte(escaped)
+			d.pos += 2
+			continue
+		}
+		if strings.IndexByte(stops, c) >= 0 {
+			break
+		}
+		builder.WriteByte(c)
+		d.pos++
+	}
+	return builder.String(), nil
+}
+
+// readDigits is a method of the textDecoder struct that reads a sequence of decimal digits.
+func (d *textDecoder) readDigits() string {
+	start := d.pos
+	for d.pos < len(d.text) && d.text[d.pos] >= '0' && d.text[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.text[start:d.pos]
+}
+
+// expect is a method of the textDecoder struct that consumes the given literal, returning an error if the text at
+// the current position does not start with it.
+func (d *textDecoder) expect(literal string) error {
+	if !strings.HasPrefix(d.text[d.pos:], literal) {
+		return d.errorf("expected %q", literal)
+	}
+	d.pos += len(literal)
+	return nil
+}
+
+// done is a method of the textDecoder struct that checks if the whole text was consumed.
+func (d *textDecoder) done() bool {
+	return d.pos >= len(d.text)
+}
+
+// errorf is a method of the textDecoder struct that builds a parse error wrapping ErrInvalidText, with the given
+// description and the current position.
+func (d *textDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidText, fmt.Sprintf(format, args...), d.pos)
+}
+
+// parseFrames is a function that parses the frames of a stack trace text, in the layout produced by GetDebugStack or
+// by the Go runtime, where each frame is a function line followed by a line with its file and line number indented
+// by a tab. Lines that don't follow this layout are ignored.
+func parseFrames(debugStack string) []Frame {
+	var frames []Frame
+	var function string
+	for _, textLine := range strings.Split(debugStack, "\n") {
+		if !strings.HasPrefix(textLine, "\t") {
+			function = textLine
+			continue
+		}
+		if function == "" {
+			continue
+		}
+		location := strings.TrimPrefix(textLine, "\t")
+		if offset := strings.LastIndex(location, " +0x"); offset >= 0 {
+			location = location[:offset]
+		}
+		separator := strings.LastIndex(location, ":")
+		if separator < 0 {
+			continue
+		}
+		line, err := strconv.Atoi(location[separator+1:])
+		if helper.IsNotNil(err) {
+			continue
+		}
+		function = trimFrameArgs(function)
+		frames = append(frames, Frame{
+			Function: function,
+			File:     location[:separator],
+			Line:     line,
+			Package:  packageName(function),
+		})
+		function = ""
+	}
+	return frames
+}
+
+// trimFrameArgs is a function that removes the arguments printed by the Go runtime after a function name,
+// for example "main.(*Type).Method(0x1, 0x2)" results in "main.(*Type).Method".
+func trimFrameArgs(function string) string {
+	if !strings.HasSuffix(function, ")") {
+		return function
+	}
+	open := strings.LastIndex(function, "(")
+	if open <= 0 || function[open-1] == '.' {
+		return function
+	}
+	return function[:open]
+}
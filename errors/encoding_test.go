@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strconv"
+	"testing"
+)
+
+func TestErrorMarshalText(t *testing.T) {
+	text, err := Details(Wrap(New("sub error [detail]"), "test error detail\nsecond line")).MarshalText()
+	logger.Info("err text:", string(text), err)
+}
+
+func TestErrorUnmarshalText(t *testing.T) {
+	var errDetail ErrorDetail
+	err := errDetail.UnmarshalText([]byte(textOf(New("test error detail"))))
+	logger.Info("err unmarshal text:", errDetail.GetCause(), err)
+	err = errDetail.UnmarshalText([]byte("test"))
+	logger.Info("err unmarshal text:", errDetail.GetCause(), err)
+
+	reused := Details(New("test error detail", StackFull))
+	reused.StackTrace()
+	if err = reused.UnmarshalText([]byte(textOf(&ErrorDetail{}))); helper.IsNotNil(err) {
+		t.Fatal("unmarshal text of the zero value error:", err)
+	}
+	if len(reused.StackTrace()) != 0 || len(reused.GetDebugStack()) != 0 {
+		t.Errorf("unmarshal text kept the previous stack trace: %q", reused.GetDebugStack())
+	}
+}
+
+func TestParse(t *testing.T) {
+	original := Details(Wrap(Wrap(errors.New("root [cause]\\"), "[CAUSE]: sub error [STACK]:"), "test error\r\ndetail "))
+	errDetail, err := Parse(textOf(original))
+	if helper.IsNotNil(err) {
+		t.Fatal("parse error:", err)
+	}
+	for expected, parsed := error(original), error(errDetail); helper.IsNotNil(expected); {
+		expectedDetail, parsedDetail := Details(expected), Details(parsed)
+		if IsErrorDetail(expected) && (expectedDetail.GetMessage() != parsedDetail.GetMessage() ||
+			expectedDetail.GetFile() != parsedDetail.GetFile() || expectedDetail.GetLine() != parsedDetail.GetLine() ||
+			expectedDetail.GetFuncName() != parsedDetail.GetFuncName() ||
+			expectedDetail.GetDebugStack() != parsedDetail.GetDebugStack()) {
+			t.Errorf("parsed %q, expected %q", parsedDetail.Error(), expectedDetail.Error())
+		} else if !IsErrorDetail(expected) && expected.Error() != parsed.Error() {
+			t.Errorf("parsed %q, expected %q", parsed.Error(), expected.Error())
+		}
+		expected, parsed = Unwrap(expected), Unwrap(parsed)
+	}
+	logger.Info("err parsed frames:", errDetail.StackTrace())
+	logger.Info("err parsed from text:", Details(errors.New("prefix: "+original.Error())).GetMessage())
+}
+
+func TestParseInvalid(t *testing.T) {
+	texts := []string{
+		"",
+		"test",
+		"[CAUSE v1]: (file.go:) func: message",
+		"[CAUSE v1]: (file.go:10) func: message [OTHER]: value",
+		"[CAUSE v1]: (file.go:10) func: message [STACK]: a [STACK]: b",
+		"[CAUSE v1]: (file.go:10) func: message\\",
+		"[CAUSE v1]: (file.go:10) func: message \\n",
+		"[CAUSE v1]: (file.go:10) func: message[STACK]: a",
+		"[CAUSE v1]: (file.go:10) func: message [WRAPS]: [ERROR]: a [b",
+	}
+	for _, text := range texts {
+		errDetail, err := Parse(text)
+		if helper.IsNotNil(errDetail) || !errors.Is(err, ErrInvalidText) {
+			t.Errorf("parse %q should fail, got %v", text, errDetail)
+		}
+		logger.Info("parse error:", err)
+	}
+}
+
+func TestParseForeignLayer(t *testing.T) {
+	inner := Details(NewCode(testCode, "inner")).With("user", 1)
+	err := Wrap(fmt.Errorf("ctx [layer]: %w", inner), "outer")
+	logger.Info("err foreign layer:", err)
+	parsed, parseErr := Parse(textOf(err))
+	if helper.IsNotNil(parseErr) {
+		t.Fatal("parse error:", parseErr)
+	}
+	if parsed.GetCode() != testCode || parsed.GetFields()["user"] != float64(1) {
+		t.Errorf("parsed code %q and fields %v of the nested layer should be kept", parsed.GetCode(), parsed.GetFields())
+	}
+	layer := parsed.Unwrap()
+	if layer.Error() != "ctx [layer]: inner" || Details(errors.Unwrap(layer)).GetLine() != inner.GetLine() {
+		t.Errorf("parsed foreign layer %q should wrap the inner detail", layer.Error())
+	}
+	if parsed.Error() != err.Error() {
+		t.Errorf("parsed %q, expected %q", parsed.Error(), err.Error())
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(textOf(New("test error detail")))
+	f.Add(textOf(Wrap(fmt.Errorf("ctx: %w", New("test error detail")), "test")))
+	f.Add(textOf(Wrap(errors.New("sub [error]"), "test\nerror")))
+	f.Add("[CAUSE v1]: (file\\:go:10) func: message [STACK]: stack [WRAPS]: [ERROR]: \\[text")
+	f.Add("[CAUSE v1]: (file.go:10) func: message [PUBLIC]: public \\[text [CODE]: CODE")
+	f.Add("[CAUSE v1]: (file.go:10) func: message [FIELDS]: {\"id\":1,\"tag\":\"\\[a]\"} [STACK]: stack")
+	f.Fuzz(func(t *testing.T, text string) {
+		errDetail, err := Parse(text)
+		if helper.IsNotNil(err) {
+			return
+		}
+		reparsed, err := Parse(textOf(errDetail))
+		if helper.IsNotNil(err) {
+			t.Fatalf("reparse of %q failed: %v", errDetail.Error(), err)
+		}
+		if reparsed.Error() != errDetail.Error() {
+			t.Fatalf("reparse of %q resulted in %q", errDetail.Error(), reparsed.Error())
+		}
+	})
+}
+
+func FuzzParseRoundTrip(f *testing.F) {
+	f.Add("errors/errors.go", uint(10), "TestNew", "test error detail", "CODE", "main.main\n\tmain.go:10\n", "cause")
+	f.Add("C:\\errors.go", uint(1), "Map[...]", "[CAUSE]: a\r\n[STACK]: b\\", "", "", "[ERROR]: c")
+	f.Fuzz(func(t *testing.T, file string, line uint, funcName, message, code, debugStack, cause string) {
+		errDetail := &ErrorDetail{
+			file:       file,
+			line:       strconv.FormatUint(uint64(line), 10),
+			funcName:   funcName,
+			message:    message,
+			debugStack: debugStack,
+			code:       Code(code),
+			cause:      &ErrorDetail{file: file, line: "1", message: cause, cause: errors.New(cause)},
+		}
+		parsed, err := Parse(textOf(errDetail))
+		if helper.IsNotNil(err) {
+			t.Fatalf("parse of %q failed: %v", errDetail.Error(), err)
+		}
+		parsedCause := Details(parsed.Unwrap())
+		if parsed.file != file || parsed.line != errDetail.line || parsed.funcName != funcName ||
+			parsed.message != message || parsed.code != Code(code) || parsed.debugStack != debugStack ||
+			parsedCause.message != cause ||
+			parsedCause.Unwrap().Error() != cause {
+			t.Fatalf("parse of %q resulted in %q", errDetail.Error(), parsed.Error())
+		}
+	})
+}
+
+// textOf returns the text encoding of the error detail of err, which is parsed by Parse in any production mode,
+// unlike the Error text, which is only a summary in the production mode.
+func textOf(err error) string {
+	text, _ := Details(err).MarshalText()
+	return string(text)
+}
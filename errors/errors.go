@@ -4,25 +4,38 @@ import (
 	"errors"
 	"fmt"
 	"github.com/GabrielHCataldo/go-helper/helper"
-	"github.com/GabrielHCataldo/go-logger/logger"
 	"regexp"
-	"runtime/debug"
 	"strings"
+	"sync"
 )
 
-const regexErrorDetail = `\[CAUSE]: \(([^:]+):(\d+)\) ([^:]+): (.+?) \[STACK]:\s*([\s\S]+)`
+// ErrUnsupported indicates that a requested operation cannot be performed, because it is unsupported.
+// It is the same value as the standard library errors.ErrUnsupported, so both can be compared with Is.
+var ErrUnsupported = errors.ErrUnsupported
+
+// regexErrorDetail matches the text of an ErrorDetail in the legacy format, used only for errors whose value was
+// lost, for example after crossing a process boundary.
+var regexErrorDetail = regexp.MustCompile(`\[CAUSE]: \(([^:]+):(\d+)\) ([^:]+): (.+?) \[STACK]:\s*([\s\S]+)`)
 
 type ErrorDetail struct {
-	file       string
-	line       string
-	funcName   string
-	message    string
-	debugStack string
+	file          string
+	line          string
+	funcName      string
+	message       string
+	publicMessage string
+	debugStack    string
+	stack         []uintptr
+	frames        []Frame
+	framesOnce    sync.Once
+	cause         error
+	origins       []error
+	code          Code
+	fields        map[string]any
 }
 
 // New is a function that creates a new error with additional error details.
 // It takes in variadic arguments `args` of any type and builds a message using `buildMessage`.
-// It then obtains the caller information using `helper.GetCallerInfo` and the current stack trace using `debug.Stack()`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
 // It returns an instance of `ErrorDetail` which contains the file, line number, function name, message, and debug stack.
 // The caller information and debug stack are used for printing the stack trace.
 //
@@ -36,21 +49,12 @@ type ErrorDetail struct {
 //	err := Newf("%s", "test error detail")
 //	fmt.Println(err.Error()) // Output: [CAUSE]: (filename:line) function: test error detail [STACK]: stack trace
 func New(args ...any) error {
-	msg := buildMessage(args...)
-	file, line, funcName := helper.GetCallerInfo(2)
-	debugStack := debug.Stack()
-	return &ErrorDetail{
-		file:       file,
-		line:       line,
-		funcName:   funcName,
-		message:    msg,
-		debugStack: string(debugStack),
-	}
+	return newDetail(1, nil, buildMessage(args...), args)
 }
 
 // Newf is a function that creates a new error with additional error details.
 // It takes in a format string and variadic arguments `args` of any type and builds a message using `buildMessageByFormat`.
-// It then obtains the caller information using `helper.GetCallerInfo` and the current stack trace using `debug.Stack()`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
 // It returns an instance of `ErrorDetail` which contains the file, line number, function name, message, and debug stack.
 // The caller information and debug stack are used for printing the stack trace.
 //
@@ -59,22 +63,13 @@ func New(args ...any) error {
 //	err := Newf("%s", "test error detail")
 //	fmt.Println(err.Error()) // Output: [CAUSE]: (filename:line) function: test error detail [STACK]: stack trace
 func Newf(format string, args ...any) error {
-	msg := buildMessageByFormat(format, args...)
-	file, line, funcName := helper.GetCallerInfo(2)
-	debugStack := debug.Stack()
-	return &ErrorDetail{
-		file:       file,
-		line:       line,
-		funcName:   funcName,
-		message:    msg,
-		debugStack: string(debugStack),
-	}
+	return newDetailf(1, nil, buildMessageByFormat(format, args...), args)
 }
 
 // NewSkipCaller is a function that creates a new error with additional error details, skipping a certain number of callers.
 // It takes in an integer argument `skipCaller` to specify the number of callers to skip.
 // It also takes in variadic arguments `args` of any type and builds a message using `buildMessage`.
-// It then obtains the caller information using `helper.GetCallerInfo` and the current stack trace using `debug.Stack()`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
 // It returns an instance of `ErrorDetail` which contains the file, line number, function name, message, and debug stack.
 // The caller information and debug stack are used for printing the stack trace.
 //
@@ -89,16 +84,7 @@ func Newf(format string, args ...any) error {
 //
 //	// Output: [CAUSE]: (filename:line) function: test error detail [STACK]: stack trace
 func NewSkipCaller(skipCaller int, args ...any) error {
-	msg := buildMessage(args...)
-	file, line, funcName := helper.GetCallerInfo(skipCaller + 1)
-	debugStack := debug.Stack()
-	return &ErrorDetail{
-		file:       file,
-		line:       line,
-		funcName:   funcName,
-		message:    msg,
-		debugStack: string(debugStack),
-	}
+	return newDetail(skipCaller, nil, buildMessage(args...), args)
 }
 
 // NewSkipCallerf is a function that creates a new error with additional error details,
@@ -106,7 +92,7 @@ func NewSkipCaller(skipCaller int, args ...any) error {
 // It takes in an integer argument `skipCaller` to specify the number of callers to skip.
 // It also takes in a format string and variadic arguments `args` of any type to build the formatted message
 // using `buildMessageByFormat`.
-// It then obtains the caller information using `helper.GetCallerInfo` and the current stack trace using `debug.Stack()`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
 // It returns an instance of `ErrorDetail` which contains the file, line number, function name, formatted message, and debug stack.
 // The caller information and debug stack are used for printing the stack trace.
 //
@@ -121,31 +107,74 @@ func NewSkipCaller(skipCaller int, args ...any) error {
 //
 //	// Output: [CAUSE]: (filename:line) function: test error detail [STACK]: stack trace
 func NewSkipCallerf(skipCaller int, format string, args ...any) error {
-	msg := buildMessageByFormat(format, args...)
-	file, line, funcName := helper.GetCallerInfo(skipCaller + 1)
-	debugStack := debug.Stack()
-	return &ErrorDetail{
-		file:       file,
-		line:       line,
-		funcName:   funcName,
-		message:    msg,
-		debugStack: string(debugStack),
+	return newDetailf(skipCaller, nil, buildMessageByFormat(format, args...), args)
+}
+
+// Wrap is a function that creates a new error with additional error details, keeping `err` as its cause.
+// It takes in the error to be wrapped and variadic arguments `args` of any type and builds a message using `buildMessage`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
+// Unlike New, the wrapped error is not flattened into the message, it is kept as the cause of the ErrorDetail and can be
+// retrieved with Unwrap, so the whole chain is preserved.
+// If `err` is nil, it returns nil.
+//
+// Usage:
+//
+//	err := Wrap(sql.ErrNoRows, "find user")
+//	fmt.Println(Details(err).GetMessage()) // Output: find user
+//	fmt.Println(Details(err).Unwrap() == sql.ErrNoRows) // Output: true
+func Wrap(err error, args ...any) error {
+	if helper.IsNil(err) {
+		return nil
+	}
+	return newDetail(1, err, buildMessage(args...), args)
+}
+
+// Wrapf is a function that creates a new error with additional error details, keeping `err` as its cause.
+// It takes in the error to be wrapped, a format string and variadic arguments `args` of any type and builds a message
+// using `buildMessageByFormat`.
+// It then obtains the caller information using `callerInfo` and the current stack trace using `callers`.
+// The wrapped error is kept as the cause of the ErrorDetail and can be retrieved with Unwrap.
+// If `err` is nil, it returns nil.
+//
+// Usage:
+//
+//	err := Wrapf(sql.ErrNoRows, "find user %d", 10)
+//	fmt.Println(Details(err).GetMessage()) // Output: find user 10
+func Wrapf(err error, format string, args ...any) error {
+	if helper.IsNil(err) {
+		return nil
 	}
+	return newDetailf(1, err, buildMessageByFormat(format, args...), args)
 }
 
 // Error is a method of the ErrorDetail struct that returns a formatted string representation of the error.
-// It returns a string in the format "[CAUSE]: (filename:line) function: message [STACK]: stack trace",
+// It returns a string in the format
+// "[CAUSE v1]: (filename:line) function: message [PUBLIC]: public message [CODE]: code [FIELDS]: fields
+// [STACK]: stack trace [WRAPS]: cause",
 // where filename represents the name of the file where the error occurred,
 // line represents the line number in the file where the error occurred,
 // function represents the name of the function where the error occurred,
 // message represents the specific error message,
-// and stack trace represents the stack trace at the time the error occurred.
+// public message represents the message safe to be shown to end users, present only for errors created with one,
+// such as by NewPublic,
+// code represents the code classifying the error, present only for errors created with a code, such as by NewCode,
+// fields represents the fields attached by With as a JSON object, present only if there are any,
+// stack trace represents the stack trace at the time the error occurred,
+// and cause represents the text of the wrapped error, present only for errors created by Wrap or Wrapf.
+// The format is versioned and lossless, see MarshalText, so the returned string can be parsed back with Parse.
 // This method is used for printing the error message along with the stack trace.
+// In the production mode, see SetProductionMode, it returns only the error message followed by the messages of the
+// wrapped causes, separated by ": ", the same as the %v verb, use MarshalText to get the versioned text.
 func (e *ErrorDetail) Error() string {
-	return fmt.Sprint("[CAUSE]: ", e.GetCause(), " [STACK]: ", e.debugStack)
+	if IsProductionMode() {
+		return e.fullMessage()
+	}
+	return e.encodeText()
 }
 
-// PrintStackTrace is a method of the ErrorDetail struct that logs the debugStack using logger.ErrorSkipCaller.
+// PrintStackTrace is a method of the ErrorDetail struct that prints the debug stack using the package Printer,
+// configured with SetPrinter, which by default logs it with logger.ErrorSkipCaller.
+// If no stack trace was captured, it prints the cause location instead, as returned by GetCause.
 // It takes no arguments and does not return anything.
 // This method is used for printing the stack trace.
 // Example usage:
@@ -153,14 +182,44 @@ func (e *ErrorDetail) Error() string {
 //	err := New("test error detail")
 //	Details(err).PrintStackTrace()
 func (e *ErrorDetail) PrintStackTrace() {
-	logger.ErrorSkipCaller(2, e.debugStack)
+	GetPrinter().Print(2, e.printableStack())
 }
 
-// PrintCause is a method of the ErrorDetail struct that logs the cause of the error using logger.ErrorSkipCaller.
+// PrintStackTraceTo is a method of the ErrorDetail struct that prints the debug stack using the given Printer,
+// instead of the package Printer.
+// Example usage:
+//
+//	err := New("test error detail")
+//	Details(err).PrintStackTraceTo(NewWriterPrinter(os.Stderr))
+func (e *ErrorDetail) PrintStackTraceTo(printer Printer) {
+	printer.Print(2, e.printableStack())
+}
+
+// printableStack is a method of the ErrorDetail struct that returns the debug stack printed by PrintStackTrace, or the
+// cause location if no stack trace was captured.
+func (e *ErrorDetail) printableStack() string {
+	if debugStack := e.GetDebugStack(); len(debugStack) != 0 {
+		return debugStack
+	}
+	return e.GetCause()
+}
+
+// PrintCause is a method of the ErrorDetail struct that prints the cause of the error using the package Printer,
+// configured with SetPrinter, which by default logs it with logger.ErrorSkipCaller.
 // It takes no arguments and does not return anything.
 // This method is used for logging the cause of the error.
 func (e *ErrorDetail) PrintCause() {
-	logger.ErrorSkipCaller(2, e.GetCause())
+	GetPrinter().Print(2, e.GetCause())
+}
+
+// PrintCauseTo is a method of the ErrorDetail struct that prints the cause of the error using the given Printer,
+// instead of the package Printer.
+// Example usage:
+//
+//	err := New("test error detail")
+//	Details(err).PrintCauseTo(NewSlogPrinter(slog.Default()))
+func (e *ErrorDetail) PrintCauseTo(printer Printer) {
+	printer.Print(2, e.GetCause())
 }
 
 // GetCause is a method of the ErrorDetail struct that returns a formatted string representation of the cause of the error.
@@ -171,11 +230,12 @@ func (e *ErrorDetail) PrintCause() {
 // and message represents the specific error message.
 // This method is used for getting the cause of the error.
 func (e *ErrorDetail) GetCause() string {
-	return fmt.Sprint("(", e.file, ":", e.line, ")", " ", e.funcName, ": ", e.message)
+	return fmt.Sprint("(", e.file, ":", e.line, ")", " ", e.funcName, ": ", e.fullMessage())
 }
 
 // GetMessage is a method of the ErrorDetail struct that returns the error message.
-// It returns a string representing the error message stored in the ErrorDetail instance.
+// It returns a string representing the error message stored in the ErrorDetail instance, without the message of
+// the wrapped cause.
 // This method is used for retrieving the error message.
 // Example usage:
 //
@@ -217,65 +277,183 @@ func (e *ErrorDetail) GetFuncName() string {
 }
 
 // GetDebugStack is a method of the ErrorDetail struct that returns the debug stack trace.
-// It returns a string representing the debug stack trace stored in the ErrorDetail instance.
+// It returns a string representing the frames of the stack trace captured when the ErrorDetail was created,
+// rendered one frame per two lines. For an ErrorDetail parsed from the text of an error, it returns the stack trace
+// text as it was parsed, and for an ErrorDetail created in the StackAllGoroutines mode, the stack traces of all
+// goroutines. In every case, the frames hidden by the package StackFilter are removed, see SetStackFilter.
+// It returns an empty string if no stack trace was captured, in the StackNone mode.
 // This method is used for retrieving the debug stack trace.
 // Example usage:
 //
 //	err := New("test error detail")
 //	stack := Details(err).GetDebugStack()
-//	fmt.Println(stack) // Output: goroutine 1 [running]:
-//	stack_trace_test.(*ErrorDetail).GetDebugStack(0x1140d81e0, 0xc00003e1b0, 0x104126d, 0xc00000c080)
-//		/path/to/file.go:42 +0x136
-//	stack_trace_test.TestErrorGetDebugStack(0xc00003e1b0)
-//		/path/to/file_test.go:11 +0x38
-//	testing.tRunner(0xc00003e1b0, 0x118b556)
-//		/path/to/testing/testing.go:1233 +0x162
-//	created by testing.(*T).Run
-//		/path/to/testing/testing.go:1274 +0x298
+//	fmt.Println(stack) // Output: stack_trace_test.TestErrorGetDebugStack
+//	//	/path/to/file_test.go:11
+//	// testing.tRunner
+//	//	/path/to/testing/testing.go:1233
 //
 // Note: The actual stack trace content may vary depending on the environment and program execution.
 func (e *ErrorDetail) GetDebugStack() string {
-	return e.debugStack
+	if len(e.debugStack) != 0 {
+		return filterStackText(e.debugStack)
+	}
+	return renderFrames(filterFrames(e.StackTrace()))
+}
+
+// StackTrace is a method of the ErrorDetail struct that returns the frames of the stack trace captured when the
+// ErrorDetail was created, starting at the function that created the error.
+// The frames are resolved from the captured program counters only on the first call, and the frames that belong to
+// this library are trimmed automatically. The frames are not filtered by the package StackFilter.
+// For an ErrorDetail parsed from the text or the JSON of an error, it returns the frames that could be parsed,
+// without their program counters.
+// Example usage:
+//
+//	err := New("test error detail")
+//	for _, frame := range Details(err).StackTrace() {
+//		fmt.Println(frame.Function, frame.File, frame.Line)
+//	}
+func (e *ErrorDetail) StackTrace() []Frame {
+	e.framesOnce.Do(func() {
+		if len(e.stack) != 0 {
+			e.frames = resolveFrames(e.stack)
+		}
+	})
+	return e.frames
+}
+
+// Unwrap is a method of the ErrorDetail struct that returns the wrapped cause of the error.
+// It returns nil if the ErrorDetail was not created by Wrap or Wrapf.
+// This method allows the standard library functions errors.Is and errors.As to walk through the chain.
+// Example usage:
+//
+//	err := Wrap(io.EOF, "read body")
+//	fmt.Println(errors.Is(err, io.EOF)) // Output: true
+func (e *ErrorDetail) Unwrap() error {
+	return e.cause
+}
+
+// Is is a method of the ErrorDetail struct that reports whether the error matches the given `target` error.
+// It returns true if any of the errors passed as arguments when the ErrorDetail was created matches the `target`,
+// so a detail created from a sentinel error matches that sentinel by identity, false otherwise.
+// Two ErrorDetail with the same code don't match each other, the codes are compared only by IsCode.
+// The wrapped cause is not checked by this method, it is reached by the standard library errors.Is through Unwrap.
+// Example usage:
+//
+//	err := New("find user:", sql.ErrNoRows)
+//	fmt.Println(errors.Is(err, sql.ErrNoRows)) // Output: true
+func (e *ErrorDetail) Is(target error) bool {
+	if helper.IsNil(target) {
+		return false
+	}
+	if code, ok := target.(codeTarget); ok && e.code != "" && e.code == Code(code) {
+		return true
+	}
+	for _, origin := range e.origins {
+		if errors.Is(origin, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// fullMessage is a method of the ErrorDetail struct that returns the error message followed by the message of the
+// wrapped cause, separated by ": ".
+// If there is no cause, it returns only the error message.
+func (e *ErrorDetail) fullMessage() string {
+	if helper.IsNil(e.cause) {
+		return e.message
+	}
+	causeMessage := errorMessage(e.cause)
+	if e.message == "" {
+		return causeMessage
+	}
+	return e.message + ": " + causeMessage
+}
+
+// errorMessage is a function that returns the message of the given error, without cause locations and stack traces.
+// If the error is an ErrorDetail, it returns its full message, otherwise it returns the redacted text of the error,
+// where the text of the first ErrorDetail of its chain, if any, is replaced with the full message of that ErrorDetail,
+// so every layer added by other error types, such as fmt.Errorf, is kept.
+func errorMessage(err error) string {
+	if errDetail, ok := err.(*ErrorDetail); ok {
+		return errDetail.fullMessage()
+	}
+	text := err.Error()
+	var errDetail *ErrorDetail
+	if !IsProductionMode() && errors.As(err, &errDetail) {
+		text = strings.Replace(text, errDetail.Error(), errDetail.fullMessage(), 1)
+	}
+	return redactText(text)
+}
+
+// unwrapLayer is a function that returns the error wrapped by `err`, an error that is not an ErrorDetail, when there
+// is an ErrorDetail in the rest of its chain, so the outputs of an ErrorDetail keep encoding the layers nested under
+// the errors of other types, such as fmt.Errorf. It returns nil otherwise, and the error is encoded by its text only.
+func unwrapLayer(err error) error {
+	next := errors.Unwrap(err)
+	var errDetail *ErrorDetail
+	if next == nil || !errors.As(next, &errDetail) {
+		return nil
+	}
+	return next
 }
 
-// Is a function that checks if the given `err` matches the given `target` error.
+// Is a function that reports whether any error in `err`'s chain matches `target`.
+// It has the same semantics as the standard library errors.Is, the chain is walked through Unwrap and an error is
+// considered to match the target if it is equal to that target (identity) or if it implements a method
+// Is(error) bool such that Is(target) returns true, as ErrorDetail does.
+// To compare errors only by their message, use IsMessage.
+// Example usage:
+//
+//	ErrNotFound := New("not found")
+//	err := Wrap(ErrNotFound, "find user")
+//	fmt.Println(Is(err, ErrNotFound)) // Output: true
+//	fmt.Println(Is(err, New("not found"))) // Output: false
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// IsNot is a function that checks if the given `err` does not match the given `target` error.
+// It negates the result of the function `Is(err, target)`. Returns true if no error in `err`'s chain matches
+// `target`, false otherwise.
+func IsNot(err, target error) bool {
+	return !Is(err, target)
+}
+
+// IsMessage is a function that checks if the given `err` has the same message as the given `target` error.
 // If both `err` and `target` are instances of ErrorDetail, it extracts the error message from each
 // and creates new errors with the extracted messages. This is to ensure that the error messages are comparable.
 // It then compares the modified `err` and `target` using the helper functions `helper.IsNotNil` and `helper.Equals`.
 // Returns true if `err` is not nil and is equal to `target`, false otherwise.
-func Is(err, target error) bool {
+// Unlike Is, two unrelated errors with the same message are considered equal. The fields attached by With are not
+// compared.
+func IsMessage(err, target error) bool {
 	if IsErrorDetail(err) {
 		errDetails := Details(err)
-		err = errors.New(errDetails.GetMessage())
+		err = errors.New(errDetails.fullMessage())
 	}
 	if IsErrorDetail(target) {
 		errDetails := Details(target)
-		target = errors.New(errDetails.GetMessage())
+		target = errors.New(errDetails.fullMessage())
 	}
 	return helper.IsNotNil(err) && helper.Equals(err, target)
 }
 
-// IsNot is a function that checks if the given `err` is not equal to the given `target` error.
-// It negates the result of the function `Is(err, target)`. Returns true if `err` is not equal to `target`,
-// false otherwise.
-func IsNot(err, target error) bool {
-	return !Is(err, target)
-}
-
 // Contains is a function that checks if an error instance or its string representation contains the target error.
 // If the input error is an instance of ErrorDetail, it extracts the error message and updates the input error to a
 // new instance of errors.New().
 // Finally, it checks if both the input error and target error are not nil and if the string representation of the
 // input error contains the string representation of the target error.
 // It returns a boolean value indicating whether the input error contains the target error.
+// The fields attached by With are not part of the compared strings.
 func Contains(err, target error) bool {
 	if IsErrorDetail(err) {
 		errDetails := Details(err)
-		err = errors.New(errDetails.GetMessage())
+		err = errors.New(errDetails.fullMessage())
 	}
 	if IsErrorDetail(target) {
 		errDetails := Details(target)
-		target = errors.New(errDetails.GetMessage())
+		target = errors.New(errDetails.fullMessage())
 	}
 	return helper.IsNotNil(err) && helper.IsNotNil(target) && strings.Contains(err.Error(), target.Error())
 }
@@ -288,33 +466,92 @@ func NotContains(err, target error) bool {
 	return !Contains(err, target)
 }
 
-// IsErrorDetail is a function that checks if the given `err` is an instance of ErrorDetail.
-// It does this by using a regular expression pattern to match the string representation of `err`.
-// Returns true if `err` is not nil and matches the pattern, false otherwise.
+// As is a function that finds the first error in `err`'s chain that matches `target`, and if one is found, sets
+// target to that error value and returns true. Otherwise, it returns false.
+// It has the same semantics as the standard library errors.As, and since ErrorDetail implements Unwrap, the chain
+// created by Wrap and Wrapf is walked through.
+// Example usage:
+//
+//	err := Wrap(&fs.PathError{Op: "open"}, "load config")
+//	var pathErr *fs.PathError
+//	fmt.Println(As(err, &pathErr)) // Output: true
+//	var errDetail *ErrorDetail
+//	fmt.Println(As(err, &errDetail)) // Output: true
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
+// Unwrap is a function that returns the result of calling the Unwrap method on `err`, if `err`'s type contains an
+// Unwrap method returning error. Otherwise, it returns nil.
+// It has the same semantics as the standard library errors.Unwrap, so for an ErrorDetail created by Wrap or Wrapf it
+// returns the wrapped cause.
+// Example usage:
+//
+//	err := Wrap(io.EOF, "read body")
+//	fmt.Println(Unwrap(err) == io.EOF) // Output: true
+func Unwrap(err error) error {
+	return errors.Unwrap(err)
+}
+
+// Join is a function that returns an error that wraps the given errors, any nil error values are discarded.
+// It has the same semantics as the standard library errors.Join, it returns nil if every value in errs is nil, and
+// the returned error can be inspected with Is and As, reaching every ErrorDetail chain joined.
+// Example usage:
+//
+//	err := Join(New("first"), Wrap(io.EOF, "second"))
+//	fmt.Println(Is(err, io.EOF)) // Output: true
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
+
+// IsErrorDetail is a function that checks if the given `err` is, or wraps, an instance of ErrorDetail.
+// It first uses errors.As to find an ErrorDetail in the chain of `err`, and only if there is none, it falls back to
+// looking for the header of the text of an ErrorDetail, in the current or in the legacy text format, in the string
+// representation of `err`, which covers errors that crossed a process boundary as text.
+// Returns true if `err` is not nil and an ErrorDetail is found, false otherwise.
 func IsErrorDetail(err error) bool {
-	regex := regexp.MustCompile(regexErrorDetail)
-	return helper.IsNotNil(err) && regex.MatchString(err.Error())
+	if helper.IsNil(err) {
+		return false
+	}
+	var errDetail *ErrorDetail
+	if errors.As(err, &errDetail) {
+		return true
+	}
+	text := err.Error()
+	return strings.Contains(text, textHeader+"(") || regexErrorDetail.MatchString(text)
 }
 
 // Details is a function that takes in an error and returns an instance of *ErrorDetail.
 // If the input error is nil, it returns nil.
-// It initializes variables file, line, funcName, message, and debugStack to empty strings.
-// It uses a regular expression to match the error message of the input error against the regexErrorDetail pattern.
+// If there is an ErrorDetail in the chain of the input error, found using errors.As, it returns that instance itself,
+// so the cause chain, the stack trace frames and every other field are preserved.
+// Otherwise, if the error message of the input error contains the text of an ErrorDetail, it returns the result of
+// parsing that text with Parse.
+// Otherwise, it initializes variables file, line, funcName, message, and debugStack to empty strings.
+// It uses a regular expression to match the error message of the input error against the legacy regexErrorDetail pattern.
 // If there is a match, it extracts the file, line, funcName, message, and debugStack from the error message.
-// Otherwise, it obtains the caller information using helper.GetCallerInfo(2) and the current stack trace using debug.Stack().
+// Otherwise, it obtains the caller information using callerInfo(2) and the current stack trace in the package
+// StackMode, which is not limited by the StackSampling, so it doesn't count toward the sampled call sites.
 // It builds the message using buildMessage(err.Error()).
 // It returns a pointer to a newly created ErrorDetail struct, with the extracted/obtained information as its field values.
 func Details(err error) *ErrorDetail {
 	if helper.IsNil(err) {
 		return nil
 	}
+	var errDetail *ErrorDetail
+	if errors.As(err, &errDetail) {
+		return errDetail
+	}
+	if errDetail, ok := parseErrorText(err.Error()); ok {
+		return errDetail
+	}
 	var file string
 	var line string
 	var funcName string
 	var message string
 	var debugStack string
-	regex := regexp.MustCompile(regexErrorDetail)
-	matches := regex.FindStringSubmatch(err.Error())
+	var stack []uintptr
+	matches := regexErrorDetail.FindStringSubmatch(err.Error())
 	if helper.IsNotEmpty(matches) {
 		file = matches[1]
 		line = matches[2]
@@ -322,8 +559,8 @@ func Details(err error) *ErrorDetail {
 		message = matches[4]
 		debugStack = matches[5]
 	} else {
-		file, line, funcName = helper.GetCallerInfo(2)
-		debugStack = string(debug.Stack())
+		file, line, funcName = callerInfo(2)
+		stack, debugStack = captureStack(1, []any{GetStackMode()})
 		message = buildMessage(err.Error())
 	}
 	return &ErrorDetail{
@@ -332,46 +569,95 @@ func Details(err error) *ErrorDetail {
 		funcName:   funcName,
 		message:    message,
 		debugStack: debugStack,
+		stack:      stack,
 	}
 }
 
 // buildMessage is a function that takes in variadic arguments `v` of any type and builds a message by
-// using the helper.Sprintln, filterMsg, and cleanMessage functions.
-// It returns the cleaned message string.
+// using the helper.Sprintln and filterMsg functions, without the trailing per-call StackMode, see stackModeArg.
+// It returns the message string, where every character of the arguments is kept as is, except for the sensitive
+// values replaced by the package Redactor, see SetRedactors.
 func buildMessage(v ...any) string {
-	return cleanMessage(helper.Sprintln(filterMsg(v...)...))
+	if _, ok := stackModeArg(v); ok {
+		v = v[:len(v)-1]
+	}
+	return redactText(helper.Sprintln(filterMsg(v...)...))
 }
 
 // buildMessageByFormat is a function that takes in a format string and variadic arguments `v` of any type.
 // It uses fmt.Sprintf to format the message string using the format and the filtered arguments.
-// It then passes the formatted message to cleanMessage to remove the "[STACK]" and "[CAUSE]" tags,
-// and replace all newline characters with a space.
-// It returns the cleaned message string.
+// It returns the formatted message string, with the sensitive values replaced by the package Redactor.
 func buildMessageByFormat(format string, v ...any) string {
-	return cleanMessage(fmt.Sprintf(format, filterMsg(v...)...))
+	return redactText(fmt.Sprintf(format, filterMsg(v...)...))
+}
+
+// parseErrorText is a function that looks for the text of an ErrorDetail, in the current text format, inside the given
+// error message and parses it with Parse.
+// It returns the parsed ErrorDetail and true if the text was found and parsed successfully, nil and false otherwise.
+func parseErrorText(text string) (*ErrorDetail, bool) {
+	index := strings.Index(text, textHeader)
+	if index < 0 {
+		return nil, false
+	}
+	errDetail, err := Parse(text[index:])
+	return errDetail, helper.IsNil(err)
 }
 
-// cleanMessage is a function that takes in a message string and removes "[STACK]" and "[CAUSE]" tags.
-// It uses strings.ReplaceAll to replace all occurrences of "[STACK]" and "[CAUSE]" with an empty string.
-// It uses the regular expression \r?\n to match all newline characters and replace them with a space.
-// It returns the cleaned message string.
-func cleanMessage(msg string) string {
-	msg = strings.ReplaceAll(msg, "[STACK]", "")
-	msg = strings.ReplaceAll(msg, "[CAUSE]", "")
-	re := regexp.MustCompile(`\r?\n`)
-	return re.ReplaceAllString(msg, " ")
+// newDetail is a function that creates a new ErrorDetail with the given cause and message, keeping the errors of
+// `args` as its origins, with the caller information and the stack trace captured in the StackMode passed as the
+// last argument of `args`, see stackModeArg.
+// It takes in the number of frames to skip, where 0 identifies the constructor calling newDetail, so the caller
+// information is the one of the function calling the constructor when `skip` is 1.
+func newDetail(skip int, cause error, msg string, args []any) *ErrorDetail {
+	file, line, funcName := callerInfo(skip + 2)
+	stack, debugStack := captureStack(skip+1, args)
+	return &ErrorDetail{
+		file:       file,
+		line:       line,
+		funcName:   funcName,
+		message:    msg,
+		debugStack: debugStack,
+		stack:      stack,
+		cause:      cause,
+		origins:    filterErrors(args...),
+	}
+}
+
+// newDetailf is a function that creates a new ErrorDetail the same way newDetail does, for the constructors with a
+// format string, whose arguments are all format operands, so none of them is taken as a per-call StackMode.
+func newDetailf(skip int, cause error, msg string, args []any) *ErrorDetail {
+	errDetail := newDetail(skip+1, cause, msg, nil)
+	errDetail.origins = filterErrors(args...)
+	return errDetail
+}
+
+// filterErrors iterates over variadic arguments and collects the arguments that are of error type.
+// It returns the collected errors, or nil if there are none.
+func filterErrors(v ...any) []error {
+	var errs []error
+	for _, iv := range v {
+		ivError, ok := iv.(error)
+		if ok && helper.IsNotNil(ivError) {
+			errs = append(errs, ivError)
+		}
+	}
+	return errs
 }
 
 // filterMsg iterates over variadic arguments and extracts error messages if the arguments are of error type.
-// It utilizes the Details function to extract the error message from error types.
+// It utilizes the errorMessage function to extract the error message from error types, replaces the Secret arguments
+// with RedactedText.
 // It returns the modified arguments with extracted error messages.
 func filterMsg(v ...any) []any {
-	for i, iv := range v {
-		ivError, ok := iv.(error)
-		if helper.IsErrorType(iv) && ok {
-			errDetail := Details(ivError)
-			v[i] = errDetail.message
+	filtered := make([]any, 0, len(v))
+	for _, iv := range v {
+		if secret, ok := iv.(Secret); ok {
+			iv = secret.String()
+		}
+		if ivError, ok := iv.(error); ok && helper.IsNotNil(ivError) {
+			iv = errorMessage(ivError)
 		}
+		filtered = append(filtered, iv)
 	}
-	return v
+	return filtered
 }
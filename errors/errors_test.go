@@ -2,7 +2,9 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"github.com/GabrielHCataldo/go-logger/logger"
+	"io/fs"
 	"testing"
 )
 
@@ -32,6 +34,56 @@ func TestNewSkipCallerf(t *testing.T) {
 	logger.Info("err:", err)
 }
 
+func TestWrap(t *testing.T) {
+	err := Wrap(errors.New("sub error"), "test error detail")
+	logger.Info("err:", err)
+	if msg := Details(err).fullMessage(); msg != "test error detail: sub error" {
+		t.Errorf("full message = %q, want %q", msg, "test error detail: sub error")
+	}
+	logger.Info("err:", Wrap(New("sub error detail"), "test error detail", 1))
+	logger.Info("err:", Wrap(err))
+	if msg := (&ErrorDetail{message: " ", cause: errors.New("sub error")}).fullMessage(); msg != " : sub error" {
+		t.Errorf("full message = %q, want %q", msg, " : sub error")
+	}
+	if Wrap(nil, "test error detail") != nil {
+		t.Error("wrap of nil error should be nil")
+	}
+}
+
+func TestWrapForeignLayer(t *testing.T) {
+	err := Wrap(fmt.Errorf("ctx layer: %w", New("inner")), "outer")
+	logger.Info("err:", err)
+	if msg := Details(err).fullMessage(); msg != "outer: ctx layer: inner" {
+		t.Errorf("full message = %q, want %q", msg, "outer: ctx layer: inner")
+	}
+	defer SetProductionMode(IsProductionMode())
+	SetProductionMode(true)
+	if msg := err.Error(); msg != "outer: ctx layer: inner" {
+		t.Errorf("production error = %q, want %q", msg, "outer: ctx layer: inner")
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	err := Wrapf(errors.New("sub error"), "%s %v", "test error detail", 1)
+	logger.Info("err:", err)
+	logger.Info("err:", Wrapf(nil, "%s", "test error detail"))
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	cause := errors.New("sub error")
+	err := Wrap(cause, "test error detail")
+	logger.Info("err unwrap:", Details(err).Unwrap())
+	if errors.Unwrap(err) != cause {
+		t.Error("unwrap should return the cause")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is should find the cause")
+	}
+	if Details(New("test error detail")).Unwrap() != nil {
+		t.Error("unwrap of an error without cause should be nil")
+	}
+}
+
 func TestIs(t *testing.T) {
 	err := errors.New("test")
 	target := New("test")
@@ -43,6 +95,60 @@ func TestIs(t *testing.T) {
 	logger.Info("errors is:", Is(nil, nil))
 }
 
+func TestIsChain(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	err := Wrap(Wrap(sentinel, "test"), "test wrap")
+	logger.Info("errors is sentinel:", Is(err, sentinel))
+	if !Is(err, sentinel) {
+		t.Error("is should find the sentinel in the wrapped chain")
+	}
+	if !Is(New("test", sentinel), sentinel) {
+		t.Error("is should find the sentinel passed as an argument")
+	}
+	if Is(err, errors.New("sentinel")) {
+		t.Error("is should not match another error with the same message")
+	}
+
+	sentinelDetail := New("sentinel detail")
+	if !Is(Wrap(sentinelDetail, "test"), sentinelDetail) {
+		t.Error("is should find the sentinel detail in the wrapped chain")
+	}
+	if Is(Wrap(sentinelDetail, "test"), New("sentinel detail")) {
+		t.Error("is should not match another detail with the same message")
+	}
+}
+
+func TestIsMessage(t *testing.T) {
+	err := errors.New("test")
+	target := New("test")
+	logger.Info("errors is message:", IsMessage(err, target))
+	if !IsMessage(err, target) {
+		t.Error("is message should match an error and a detail with the same message")
+	}
+
+	errDetail := New("test")
+	targetDetail := New("test")
+	if !IsMessage(errDetail, targetDetail) {
+		t.Error("is message should match two details with the same message")
+	}
+	if IsMessage(nil, nil) {
+		t.Error("is message should be false for a nil error")
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	if !New("test", sentinel).(*ErrorDetail).Is(sentinel) {
+		t.Error("detail should match the sentinel passed as an argument")
+	}
+	if New("test").(*ErrorDetail).Is(sentinel) {
+		t.Error("detail without arguments should not match the sentinel")
+	}
+	if New("test", sentinel).(*ErrorDetail).Is(nil) {
+		t.Error("detail should not match a nil target")
+	}
+}
+
 func TestIsNot(t *testing.T) {
 	err := errors.New("test")
 	target := New("test2")
@@ -130,3 +236,25 @@ func TestDetails(t *testing.T) {
 	logger.Info("err details:", Details(errors.New("test")))
 	logger.Info("err details:", Details(New("test")))
 }
+
+func TestAs(t *testing.T) {
+	err := Wrap(&fs.PathError{Op: "open", Path: "test", Err: ErrUnsupported}, "test error detail")
+	var pathErr *fs.PathError
+	logger.Info("errors as path error:", As(err, &pathErr), pathErr)
+	var errDetail *ErrorDetail
+	logger.Info("errors as error detail:", As(err, &errDetail))
+	logger.Info("errors as error detail:", As(errors.New("test"), &errDetail))
+}
+
+func TestUnwrap(t *testing.T) {
+	logger.Info("errors unwrap:", Unwrap(Wrap(ErrUnsupported, "test error detail")))
+	logger.Info("errors unwrap:", Unwrap(New("test error detail")))
+	logger.Info("errors unwrap:", Unwrap(nil))
+}
+
+func TestJoin(t *testing.T) {
+	err := Join(New("test error detail"), Wrap(ErrUnsupported, "test error detail 2"), nil)
+	logger.Info("errors join:", err)
+	logger.Info("errors join is unsupported:", errors.Is(err, ErrUnsupported))
+	logger.Info("errors join:", Join(nil, nil))
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// With is a method of the ErrorDetail struct that attaches the given key-value field to the ErrorDetail, such as a
+// request ID, a user ID or an entity key, so facts about the error are kept without being part of the message, and
+// returns the same ErrorDetail to allow chaining.
+// A field with the same key as a previous one replaces it. The fields are part of the JSON, slog and %+v outputs
+// of the error, but not of its message, so they are ignored by IsMessage and Contains.
+// A string value has the sensitive values replaced by the package Redactor, see SetRedactors, and a Secret value is
+// always written as RedactedText.
+// It modifies the ErrorDetail, so it must be called only on errors just created, before they are shared, and never
+// on package-level sentinel errors.
+// Example usage:
+//
+//	err := Details(New("user not found")).With("user_id", 10).With("tenant", "acme")
+//	fmt.Println(err.GetFields()) // Output: map[tenant:acme user_id:10]
+func (e *ErrorDetail) With(key string, value any) *ErrorDetail {
+	if e.fields == nil {
+		e.fields = map[string]any{}
+	}
+	e.fields[key] = redactValue(value)
+	return e
+}
+
+// GetFields is a method of the ErrorDetail struct that returns the fields attached by With to the ErrorDetail and to
+// every ErrorDetail of its cause chain, merged in a new map, where the fields of the outer layers override the ones
+// with the same key of the inner layers.
+// Example usage:
+//
+//	cause := Details(New("user not found")).With("user_id", 10).With("layer", "repository")
+//	err := Details(Wrap(cause, "get user")).With("layer", "service")
+//	fmt.Println(err.GetFields()) // Output: map[layer:service user_id:10]
+func (e *ErrorDetail) GetFields() map[string]any {
+	fields := map[string]any{}
+	e.mergeFields(fields)
+	return fields
+}
+
+// mergeFields is a method of the ErrorDetail struct that copies to the given map the fields of the cause chain,
+// from the innermost layer to this one, so the outer layers override the inner ones.
+func (e *ErrorDetail) mergeFields(fields map[string]any) {
+	var causeDetail *ErrorDetail
+	if errors.As(e.cause, &causeDetail) {
+		causeDetail.mergeFields(fields)
+	}
+	for key, value := range e.fields {
+		fields[key] = value
+	}
+}
+
+// encodableFields is a function that returns a copy of the given fields where each value that can't be encoded to
+// JSON, such as a channel or a function, is replaced with its text formatted by fmt.Sprint, or nil if there are no
+// fields.
+func encodableFields(fields map[string]any) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	encodable := make(map[string]any, len(fields))
+	for key, value := range fields {
+		if _, err := json.Marshal(value); err != nil {
+			value = fmt.Sprint(value)
+		}
+		encodable[key] = value
+	}
+	return encodable
+}
+
+// sortedFieldKeys is a function that returns the keys of the given fields in ascending order, so the fields are
+// always written in the same order.
+func sortedFieldKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeFields is a function that writes the given fields to the writer as a line in the format
+// "Fields: key=value key=value", used by the %+v verb of Format. Nothing is written if there are no fields.
+func writeFields(w io.Writer, fields map[string]any) {
+	if len(fields) == 0 {
+		return
+	}
+	_, _ = io.WriteString(w, "Fields:")
+	for _, key := range sortedFieldKeys(fields) {
+		_, _ = fmt.Fprintf(w, " %s=%v", key, fields[key])
+	}
+	_, _ = io.WriteString(w, "\n")
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	err := Details(New("test error detail")).With("user_id", 10).With("tenant", "acme").With("user_id", 11)
+	logger.Info("err:", err)
+	logger.Info(fmt.Sprintf("err verbose: %+v", err))
+	if err.GetFields()["user_id"] != 11 {
+		t.Error("field should be replaced:", err.GetFields())
+	}
+	if !IsMessage(err, New("test error detail")) || !Contains(err, New("error detail")) {
+		t.Error("fields should not be compared")
+	}
+}
+
+func TestGetFields(t *testing.T) {
+	cause := Details(New("test cause")).With("layer", "repository").With("user_id", 10)
+	err := Details(Wrap(fmt.Errorf("wrap: %w", cause), "test error detail")).With("layer", "service")
+	fields := err.GetFields()
+	logger.Info("err fields:", fields)
+	if fields["layer"] != "service" || fields["user_id"] != 10 {
+		t.Error("outer fields should override the inner ones:", fields)
+	}
+	logger.Info("err without fields:", Details(New("test")).GetFields())
+}
+
+func TestFieldsEncoding(t *testing.T) {
+	err := Details(Wrap(Details(New("test cause")).With("user_id", 10), "test [error] detail")).
+		With("request_id", "abc[1]").
+		With("callback", func() {})
+	parsed, parseErr := Parse(textOf(err))
+	if parseErr != nil {
+		t.Fatal("parse failed:", parseErr)
+	}
+	logger.Info("err parsed fields:", parsed.GetFields())
+	if parsed.GetFields()["request_id"] != "abc[1]" || parsed.GetFields()["user_id"] != float64(10) {
+		t.Error("fields should be parsed back:", parsed.GetFields())
+	}
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatal("marshal failed:", jsonErr)
+	}
+	logger.Info("err json:", string(data))
+	parsedJSON, _ := ParseJSON(data)
+	if parsedJSON.GetFields()["request_id"] != "abc[1]" {
+		t.Error("fields should be decoded back:", parsedJSON.GetFields())
+	}
+	_, parseErr = Parse("[CAUSE v1]: (file.go:1) func: message [FIELDS]: {invalid")
+	logger.Info("err invalid fields:", parseErr)
+}
+
+func TestFieldsLogValue(t *testing.T) {
+	var buffer bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buffer, nil))
+	log.Error("test", "err", Details(New("test error detail")).With("user_id", 10))
+	logger.Info("slog output:", buffer.String())
+	if !strings.Contains(buffer.String(), `"fields":{"user_id":10}`) {
+		t.Error("fields should be logged")
+	}
+}
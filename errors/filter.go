@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+// StackFilter are the options used to hide frames of the stack traces written by the %+v verb, the JSON encoding,
+// the print helpers, such as PrintStackTrace, and every other output based on GetDebugStack, so only the
+// application frames are shown. The frames returned by StackTrace are never filtered.
+// The zero value hides no frames.
+type StackFilter struct {
+	// HideLibrary hides the frames of this package.
+	HideLibrary bool
+	// HideRuntime hides the frames of the runtime package and its subpackages, such as runtime/debug.
+	HideRuntime bool
+	// HideStdlib hides the frames of the standard library packages, such as testing and net/http, recognized by
+	// the first element of the import path without a dot, except for the main package and the main module.
+	HideStdlib bool
+	// KeepPrefixes hides the frames whose package import path doesn't start with any of the prefixes, for example
+	// "github.com/user/project/". If it is empty, no frames are hidden by prefix.
+	KeepPrefixes []string
+	// Collapse replaces each run of consecutive hidden frames with a single marker frame "... N frames elided".
+	Collapse bool
+}
+
+// stackFilter is the package StackFilter.
+var stackFilter StackFilter
+
+// stackFilterMutex guards the package StackFilter.
+var stackFilterMutex sync.RWMutex
+
+// SetStackFilter is a function that sets the package StackFilter, applied to the stack traces written by the
+// outputs of the errors.
+// Example usage:
+//
+//	SetStackFilter(StackFilter{HideLibrary: true, HideRuntime: true, HideStdlib: true, Collapse: true})
+//	fmt.Printf("%+v\n", New("test error detail"))
+//	// Output: (main.go:10) main: test error detail
+//	// main.main
+//	//	main.go:10
+//	// ... 1 frame elided
+func SetStackFilter(filter StackFilter) {
+	filter.KeepPrefixes = append([]string(nil), filter.KeepPrefixes...)
+	stackFilterMutex.Lock()
+	defer stackFilterMutex.Unlock()
+	stackFilter = filter
+}
+
+// GetStackFilter is a function that returns the package StackFilter.
+func GetStackFilter() StackFilter {
+	stackFilterMutex.RLock()
+	defer stackFilterMutex.RUnlock()
+	return stackFilter
+}
+
+// filterFrames is a function that returns the given frames without the ones hidden by the package StackFilter,
+// replacing each run of hidden frames with a marker frame if the Collapse option is enabled.
+func filterFrames(frames []Frame) []Frame {
+	filter := GetStackFilter()
+	if !filter.HideLibrary && !filter.HideRuntime && !filter.HideStdlib && len(filter.KeepPrefixes) == 0 {
+		return frames
+	}
+	var filtered []Frame
+	elided := 0
+	for _, frame := range frames {
+		if filter.hides(frame) {
+			elided++
+			continue
+		}
+		if elided != 0 && filter.Collapse {
+			filtered = append(filtered, Frame{Elided: elided})
+		}
+		elided = 0
+		filtered = append(filtered, frame)
+	}
+	if elided != 0 && filter.Collapse {
+		filtered = append(filtered, Frame{Elided: elided})
+	}
+	return filtered
+}
+
+// filterStackText is a function that hides, from a stack trace text, the frames hidden by the package StackFilter,
+// replacing each run of hidden frames with a marker line if the Collapse option is enabled. Each frame of the text is
+// a function line followed by its file location line, indented by a tab, as printed by the Go runtime or rendered by
+// renderFrames, the other lines, such as the goroutine headers, are kept.
+func filterStackText(stack string) string {
+	filter := GetStackFilter()
+	if !filter.HideLibrary && !filter.HideRuntime && !filter.HideStdlib && len(filter.KeepPrefixes) == 0 {
+		return stack
+	}
+	lines := strings.Split(stack, "\n")
+	filtered := make([]string, 0, len(lines))
+	elided := 0
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			function := stackLineFunction(lines[i])
+			file := strings.TrimPrefix(lines[i+1], "\t")
+			if index := strings.LastIndex(file, ":"); index >= 0 {
+				file = file[:index]
+			}
+			frame := Frame{Function: function, File: file, Package: packageName(function)}
+			if filter.hides(frame) {
+				elided++
+				i++
+				continue
+			}
+		}
+		if elided != 0 && filter.Collapse {
+			filtered = append(filtered, Frame{Elided: elided}.String())
+		}
+		elided = 0
+		filtered = append(filtered, lines[i])
+	}
+	if elided != 0 && filter.Collapse {
+		filtered = append(filtered, Frame{Elided: elided}.String())
+	}
+	return strings.Join(filtered, "\n")
+}
+
+// hides is a method of the StackFilter struct that checks if the given frame is hidden by the filter.
+func (f StackFilter) hides(frame Frame) bool {
+	if f.HideLibrary && isLibraryFrame(frame) {
+		return true
+	} else if f.HideRuntime && (frame.Package == "runtime" || strings.HasPrefix(frame.Package, "runtime/")) {
+		return true
+	} else if f.HideStdlib && isStdlibPackage(frame.Package) {
+		return true
+	}
+	if len(f.KeepPrefixes) == 0 {
+		return false
+	}
+	for _, prefix := range f.KeepPrefixes {
+		if strings.HasPrefix(frame.Package, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// isStdlibPackage is a function that checks if the given package import path is of the standard library, whose
+// first element has no dot, excluding the main package and the packages of the main module.
+func isStdlibPackage(pkg string) bool {
+	if pkg == "main" {
+		return false
+	} else if len(mainModulePath) != 0 && (pkg == mainModulePath || strings.HasPrefix(pkg, mainModulePath+"/")) {
+		return false
+	}
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"testing"
+)
+
+func TestStackFilter(t *testing.T) {
+	SetStackFilter(StackFilter{HideRuntime: true, HideStdlib: true, Collapse: true})
+	defer SetStackFilter(StackFilter{})
+	errDetail := Details(New("test error detail"))
+	verbose := fmt.Sprintf("%+v", errDetail)
+	logger.Info("err verbose:", verbose)
+	if strings.Contains(verbose, "testing.tRunner") || !strings.Contains(verbose, "... 1 frame elided") {
+		t.Error("standard library frames should be collapsed:", verbose)
+	}
+	data, _ := json.Marshal(errDetail)
+	logger.Info("err json:", string(data))
+	parsed, _ := ParseJSON(data)
+	if !strings.Contains(parsed.GetDebugStack(), "... 1 frame elided") {
+		t.Error("elided frames marker should be encoded in json:", parsed.GetDebugStack())
+	}
+	if len(errDetail.StackTrace()) != 2 {
+		t.Error("stack trace should not be filtered:", errDetail.StackTrace())
+	}
+	errDetail.PrintStackTrace()
+}
+
+func TestStackFilterKeepPrefixes(t *testing.T) {
+	SetStackFilter(StackFilter{KeepPrefixes: []string{"github.com/other/"}})
+	defer SetStackFilter(StackFilter{})
+	if debugStack := Details(New("test error detail")).GetDebugStack(); len(debugStack) != 0 {
+		t.Error("frames without the prefixes should be hidden:", debugStack)
+	}
+	if GetStackFilter().KeepPrefixes[0] != "github.com/other/" {
+		t.Error("stack filter should be returned")
+	}
+}
+
+func TestStackFilterHides(t *testing.T) {
+	filter := StackFilter{HideLibrary: true, HideRuntime: true, HideStdlib: true}
+	testCases := map[string]bool{
+		packagePath:                        true,
+		"runtime/debug":                    true,
+		"net/http":                         true,
+		"main":                             false,
+		mainModulePath + "/errors_test":    false,
+		"github.com/user/project/internal": false,
+	}
+	for pkg, expected := range testCases {
+		if filter.hides(Frame{Package: pkg, File: "file.go"}) != expected {
+			t.Errorf("package %s hidden should be %v", pkg, expected)
+		}
+	}
+	if (StackFilter{}).hides(Frame{Package: "runtime"}) {
+		t.Error("zero stack filter should hide no frames")
+	}
+	logger.Info("frame elided:", Frame{Elided: 2}.String())
+}
+
+func TestStackFilterText(t *testing.T) {
+	parsed, _ := Parse(textOf(New("test error detail")))
+	SetStackFilter(StackFilter{HideRuntime: true, HideStdlib: true, Collapse: true})
+	defer SetStackFilter(StackFilter{})
+	stack := "goroutine 1 [running]:\nmain.(*server).run(...)\n\tapp/main.go:10\nruntime.main()\n\truntime/proc.go:267\n" +
+		"runtime.goexit({})\n\truntime/asm_amd64.s:1650\n\ngoroutine 2 [select]:\nnet/http.(*Server).Serve(...)\n" +
+		"\tnet/http/server.go:3000\ncreated by main.main in goroutine 1\n\tapp/main.go:5\n"
+	filtered := filterStackText(stack)
+	logger.Info("filtered stack:", filtered)
+	expected := "goroutine 1 [running]:\nmain.(*server).run(...)\n\tapp/main.go:10\n... 2 frames elided\n\n" +
+		"goroutine 2 [select]:\n... 1 frame elided\ncreated by main.main in goroutine 1\n\tapp/main.go:5\n"
+	if filtered != expected {
+		t.Errorf("expected %q, got %q", expected, filtered)
+	}
+	errAll := Details(New("test error detail", StackAllGoroutines))
+	if debugStack := errAll.GetDebugStack(); strings.Contains(debugStack, "testing.tRunner") {
+		t.Error("all goroutines stack should be filtered:", debugStack)
+	}
+	if debugStack := parsed.GetDebugStack(); strings.Contains(debugStack, "testing.tRunner") {
+		t.Error("parsed stack should be filtered:", debugStack)
+	}
+}
+
+func TestIsStdlibPackage(t *testing.T) {
+	defer func(modulePath string) { mainModulePath = modulePath }(mainModulePath)
+	mainModulePath = "example"
+	testCases := map[string]bool{
+		"example":        false,
+		"example/pkg":    false,
+		"examples/pkg":   true,
+		"net/http":       true,
+		"github.com/a/b": false,
+		"main":           false,
+	}
+	for pkg, expected := range testCases {
+		if isStdlibPackage(pkg) != expected {
+			t.Errorf("package %s standard library should be %v", pkg, expected)
+		}
+	}
+}
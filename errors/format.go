@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"io"
+)
+
+// Format is a method of the ErrorDetail struct that implements the fmt.Formatter interface, controlling how the
+// error is printed by the fmt functions and by loggers that rely on them.
+// The verbs supported are:
+//
+//	%s    the error message followed by the messages of the wrapped causes, separated by ": "
+//	%v    same as %s
+//	%q    the same message of %s, double-quoted and safely escaped with Go syntax
+//	%+v   for each layer of the chain, the cause location "(file:line) function: message" followed by the fields
+//	      attached by With, in a line "Fields: key=value", and by the full multi-line stack trace, the layers of the
+//	      wrapped causes are introduced by "Caused by: ", including the errors of other types wrapping them, such as
+//	      fmt.Errorf, printed by their message
+//
+// Use the Error method to get the versioned text with every field of the error.
+// Example usage:
+//
+//	err := Wrap(io.EOF, "read body")
+//	fmt.Printf("%v\n", err) // Output: read body: EOF
+//	fmt.Printf("%q\n", err) // Output: "read body: EOF"
+//	fmt.Printf("%+v\n", err) // Output: (main.go:10) main: read body
+//	// main.main
+//	//	/path/to/main.go:10
+//	// Caused by: EOF
+func (e *ErrorDetail) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			e.writeVerbose(s)
+			return
+		}
+		_, _ = io.WriteString(s, e.fullMessage())
+	case 's':
+		_, _ = io.WriteString(s, e.fullMessage())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.fullMessage())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(*errors.ErrorDetail=%s)", verb, e.fullMessage())
+	}
+}
+
+// writeVerbose is a method of the ErrorDetail struct that writes the cause location and the stack trace of each layer
+// of the chain to the given writer, used by the %+v verb of Format.
+func (e *ErrorDetail) writeVerbose(w io.Writer) {
+	_, _ = fmt.Fprint(w, "(", e.file, ":", e.line, ") ", e.funcName, ": ", e.message, "\n")
+	writeFields(w, e.fields)
+	_, _ = io.WriteString(w, e.GetDebugStack())
+	if helper.IsNil(e.cause) {
+		return
+	}
+	for cause := e.cause; ; {
+		_, _ = io.WriteString(w, "Caused by: ")
+		if causeDetail, ok := cause.(*ErrorDetail); ok {
+			causeDetail.writeVerbose(w)
+			return
+		}
+		next := unwrapLayer(cause)
+		if next == nil {
+			_, _ = io.WriteString(w, redactText(fmt.Sprintf("%+v", cause)))
+			return
+		}
+		_, _ = io.WriteString(w, errorMessage(cause)+"\n")
+		cause = next
+	}
+}
@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"testing"
+)
+
+func TestErrorFormat(t *testing.T) {
+	err := Wrap(Wrap(errors.New("sub error"), "test error detail"), "test \"wrap\"")
+	logger.Info("err %s:", fmt.Sprintf("%s", err))
+	logger.Info("err %v:", fmt.Sprintf("%v", err))
+	logger.Info("err %q:", fmt.Sprintf("%q", err))
+	logger.Info("err %+v:", fmt.Sprintf("%+v", err))
+	logger.Info("err %d:", fmt.Sprintf("%d", err))
+	if fmt.Sprint(err) != "test \"wrap\": test error detail: sub error" {
+		t.Errorf("unexpected %%v output: %s", err)
+	}
+}
+
+func TestErrorFormatForeignLayer(t *testing.T) {
+	inner := Details(New("inner"))
+	verbose := fmt.Sprintf("%+v", Wrap(fmt.Errorf("ctx: %w", inner), "outer"))
+	logger.Info("err %+v foreign layer:", verbose)
+	location := fmt.Sprint("Caused by: ctx: inner\nCaused by: (", inner.GetFile(), ":", inner.GetLine(), ") ")
+	if !strings.Contains(verbose, location) || strings.Contains(verbose, textHeader) {
+		t.Error("nested layer should be printed with its location:", verbose)
+	}
+}
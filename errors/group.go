@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// GroupOptions are the options used to create a Group.
+type GroupOptions struct {
+	// Limit is the maximum number of goroutines of the group running at the same time, Go blocks until a running
+	// goroutine returns when the limit is reached. If it is zero or negative, there is no limit.
+	Limit int
+	// FailFast enables the first-error-cancels semantics: the first error returned by a goroutine cancels the group
+	// context, and Wait returns only that error. If it is false, every goroutine runs to completion, and Wait returns
+	// all the errors in a MultiError.
+	FailFast bool
+}
+
+// Group is a collection of goroutines working on subtasks of a common task, which collects the errors, and the
+// panics converted into ErrorDetail, returned by them, keeping the cause location and stack trace of each one.
+// The zero value is a valid Group, without context, without limit and collecting all errors.
+// A Group must not be copied after first use.
+type Group struct {
+	cancel  context.CancelCauseFunc
+	options GroupOptions
+	sem     chan struct{}
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	errs    []error
+}
+
+// NewGroup is a function that returns a new Group with the given options, and a context derived from `ctx`, which
+// is canceled when a goroutine returns an error with the FailFast option, or when Wait returns, whichever occurs
+// first.
+// Example usage:
+//
+//	group, ctx := NewGroup(ctx, GroupOptions{Limit: 10})
+//	for _, user := range users {
+//		group.Go(func() error {
+//			return notify(ctx, user)
+//		})
+//	}
+//	if err := group.Wait(); err != nil {
+//		fmt.Printf("%+v\n", err) // Output: every error with its cause location and stack trace
+//	}
+func NewGroup(ctx context.Context, options GroupOptions) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	group := &Group{cancel: cancel, options: options}
+	if options.Limit > 0 {
+		group.sem = make(chan struct{}, options.Limit)
+	}
+	return group, ctx
+}
+
+// Go is a method that calls the function `fn` in a new goroutine of the group, recovering its panics, blocking
+// while the group has its limit of running goroutines.
+// If `fn` returns an error, or panics, the error is collected to be returned by Wait, the panic as an ErrorDetail
+// whose cause location is the function that panicked.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := runRecovered(fn); err != nil {
+			g.collect(err)
+		}
+	}()
+}
+
+// Wait is a method that blocks until all goroutines of the group have returned, then returns the errors collected,
+// or nil if there are none.
+// With the FailFast option, it returns the first error, otherwise it returns all the errors in a MultiError, in the
+// order in which they occurred, each one reachable by Is, As and Details.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if len(g.errs) == 0 {
+		return nil
+	} else if g.options.FailFast {
+		return g.errs[0]
+	}
+	return NewMultiError(g.errs...)
+}
+
+// collect is a method that records the error returned by a goroutine of the group, canceling the group context if
+// it is the first error with the FailFast option.
+func (g *Group) collect(err error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.options.FailFast && len(g.errs) == 0 && g.cancel != nil {
+		g.cancel(err)
+	}
+	g.errs = append(g.errs, err)
+}
+
+// done is a method that releases the slot of a returned goroutine of the group.
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	var group Group
+	sentinel := errors.New("test sentinel")
+	group.Go(func() error {
+		return New("test error detail", sentinel)
+	})
+	group.Go(func() error {
+		panicTest()
+		return nil
+	})
+	group.Go(func() error {
+		return nil
+	})
+	err := group.Wait()
+	logger.Info("group err:", fmt.Sprintf("%+v", err))
+	if !Is(err, sentinel) {
+		t.Error("group error should keep every goroutine error:", err)
+	}
+	var count int
+	for _, wrapped := range err.(interface{ Unwrap() []error }).Unwrap() {
+		if IsErrorDetail(wrapped) {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("group error should join 2 error details, got %d", count)
+	}
+}
+
+func TestGroupNil(t *testing.T) {
+	group, _ := NewGroup(context.TODO(), GroupOptions{})
+	group.Go(func() error {
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		t.Error("group error should be nil:", err)
+	}
+}
+
+func TestGroupFailFast(t *testing.T) {
+	group, ctx := NewGroup(context.TODO(), GroupOptions{FailFast: true})
+	errDetail := New("test error detail")
+	group.Go(func() error {
+		return errDetail
+	})
+	group.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	})
+	err := group.Wait()
+	if err != errDetail {
+		t.Error("group error should be the first error:", err)
+	}
+	logger.Info("group context cause:", context.Cause(ctx))
+}
+
+func TestGroupLimit(t *testing.T) {
+	group, ctx := NewGroup(context.TODO(), GroupOptions{Limit: 2})
+	var running, maxRunning int32
+	for i := 0; i < 10; i++ {
+		group.Go(func() error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&maxRunning)
+				if current <= old || atomic.CompareAndSwapInt32(&maxRunning, old, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	logger.Info("group wait:", group.Wait())
+	if maxRunning > 2 {
+		t.Errorf("group should run at most 2 goroutines, ran %d", maxRunning)
+	}
+	if ctx.Err() == nil {
+		t.Error("group context should be canceled after wait")
+	}
+}
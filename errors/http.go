@@ -0,0 +1,183 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"net/http"
+	"sync"
+)
+
+// ProblemContentType is the media type of the responses written by WriteProblem, defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem details object, defined by RFC 7807, describing an error in an HTTP response body.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type, "about:blank" when the type is unknown.
+	Type string `json:"type"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies the specific occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Extensions are additional members of the problem details object, such as "code", written at the same level
+	// of the members above, which take precedence on conflicts.
+	Extensions map[string]any `json:"-"`
+}
+
+// ProblemOptions are the options used to build a Problem from an error.
+type ProblemOptions struct {
+	// Debug enables the extension members with internal details of the error: "file", "line", "function" and
+	// "frames". It must not be enabled in production, since it exposes the source code structure.
+	Debug bool
+	// TypeBaseURI is the base URI of the problem types, the type of a problem is this URI followed by the code of
+	// the error. If it is empty, or the error has no code, the type is "about:blank".
+	TypeBaseURI string
+	// Instance is the URI reference that identifies the specific occurrence of the problem, usually the request path.
+	Instance string
+}
+
+// httpStatuses maps the error codes registered by RegisterHTTPStatus to HTTP status codes.
+var httpStatuses = map[Code]int{}
+
+// httpStatusesMutex guards httpStatuses.
+var httpStatusesMutex sync.RWMutex
+
+// problemOptions are the package ProblemOptions, used by WriteProblem.
+var problemOptions ProblemOptions
+
+// problemOptionsMutex guards the package ProblemOptions.
+var problemOptionsMutex sync.RWMutex
+
+// RegisterHTTPStatus is a function that maps the given error code to an HTTP status code, used by HTTPStatus and by
+// the problem writers for errors classified by that code.
+// Example usage:
+//
+//	RegisterHTTPStatus(CodeUserNotFound, http.StatusNotFound)
+//	fmt.Println(HTTPStatus(NewCode(CodeUserNotFound, "user not found"))) // Output: 404
+func RegisterHTTPStatus(code Code, status int) {
+	httpStatusesMutex.Lock()
+	defer httpStatusesMutex.Unlock()
+	httpStatuses[code] = status
+}
+
+// HTTPStatus is a function that returns the HTTP status code for the given error.
+// It returns the status registered with RegisterHTTPStatus for the code of the first ErrorDetail in the chain of
+// `err`, walking to the nearest coded layer as GetCode does, or http.StatusInternalServerError if there is no such
+// registration. If `err` is nil, it returns http.StatusOK.
+func HTTPStatus(err error) int {
+	if helper.IsNil(err) {
+		return http.StatusOK
+	}
+	var errDetail *ErrorDetail
+	if !errors.As(err, &errDetail) {
+		return http.StatusInternalServerError
+	}
+	httpStatusesMutex.RLock()
+	defer httpStatusesMutex.RUnlock()
+	if status, ok := httpStatuses[errDetail.GetCode()]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// SetProblemOptions is a function that sets the package ProblemOptions, used by WriteProblem.
+// Example usage:
+//
+//	SetProblemOptions(ProblemOptions{TypeBaseURI: "https://example.com/problems/", Debug: os.Getenv("DEBUG") != ""})
+func SetProblemOptions(opts ProblemOptions) {
+	problemOptionsMutex.Lock()
+	defer problemOptionsMutex.Unlock()
+	problemOptions = opts
+}
+
+// getProblemOptions is a function that returns the package ProblemOptions.
+func getProblemOptions() ProblemOptions {
+	problemOptionsMutex.RLock()
+	defer problemOptionsMutex.RUnlock()
+	return problemOptions
+}
+
+// NewProblem is a function that builds the Problem describing the given error, using the given options.
+// The status is obtained with HTTPStatus, the title is the text of that status, the detail is the public message of
+// the error, returned by GetPublicMessage, never its internal message, and the code of the error, if there is one,
+// is added as the "code" extension member. The internal details of the error, such as the file, line and stack
+// trace, are added only if the Debug option is enabled.
+func NewProblem(err error, opts ProblemOptions) Problem {
+	status := HTTPStatus(err)
+	problem := Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Instance:   opts.Instance,
+		Extensions: map[string]any{},
+	}
+	if helper.IsNil(err) {
+		return problem
+	}
+	problem.Detail = publicMessage(err)
+	var errDetail *ErrorDetail
+	if !errors.As(err, &errDetail) {
+		return problem
+	}
+	if code := errDetail.GetCode(); code != "" {
+		problem.Extensions["code"] = code
+		if opts.TypeBaseURI != "" {
+			problem.Type = opts.TypeBaseURI + string(code)
+		}
+	}
+	if opts.Debug {
+		problem.Extensions["file"] = errDetail.file
+		problem.Extensions["line"] = errDetail.GetLine()
+		problem.Extensions["function"] = errDetail.funcName
+		problem.Extensions["frames"] = filterFrames(errDetail.StackTrace())
+	}
+	return problem
+}
+
+// WriteProblem is a function that writes the given error to the response as an RFC 7807 problem details object,
+// with the "application/problem+json" content type and the status returned by HTTPStatus, using the package
+// ProblemOptions set by SetProblemOptions.
+// Example usage:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		if err := service(r.Context()); err != nil {
+//			WriteProblem(w, err)
+//			return
+//		}
+//	}
+func WriteProblem(w http.ResponseWriter, err error) {
+	WriteProblemOpts(w, err, getProblemOptions())
+}
+
+// WriteProblemOpts is a function that writes the given error to the response as an RFC 7807 problem details
+// object, the same way WriteProblem does, but using the given options instead of the package ProblemOptions.
+func WriteProblemOpts(w http.ResponseWriter, err error, opts ProblemOptions) {
+	problem := NewProblem(err, opts)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// MarshalJSON is a method of the Problem struct that implements the json.Marshaler interface.
+// It returns the JSON object with the standard members of the problem and the extension members at the same level.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
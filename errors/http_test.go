@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testNotFoundCode Code = "TEST_NOT_FOUND"
+
+func TestHTTPStatus(t *testing.T) {
+	RegisterHTTPStatus(testNotFoundCode, http.StatusNotFound)
+	if status := HTTPStatus(Wrap(NewCode(testNotFoundCode, "test"), "test wrap")); status != http.StatusNotFound {
+		t.Error("unexpected status:", status)
+	}
+	logger.Info("http status:", HTTPStatus(New("test")))
+	logger.Info("http status:", HTTPStatus(errors.New("test")))
+	logger.Info("http status:", HTTPStatus(nil))
+}
+
+func TestNewProblem(t *testing.T) {
+	RegisterHTTPStatus(testNotFoundCode, http.StatusNotFound)
+	problem := NewProblem(NewCode(testNotFoundCode, "test error detail"), ProblemOptions{
+		TypeBaseURI: "https://example.com/problems/",
+		Instance:    "/test",
+	})
+	data, _ := json.Marshal(problem)
+	logger.Info("problem:", string(data))
+	problem = NewProblem(New("test error detail"), ProblemOptions{Debug: true})
+	data, _ = json.Marshal(problem)
+	logger.Info("problem debug:", string(data))
+	logger.Info("problem:", NewProblem(errors.New("test"), ProblemOptions{}).Detail)
+	logger.Info("problem:", NewProblem(nil, ProblemOptions{}).Status)
+}
+
+func TestWriteProblem(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteProblem(recorder, New("test error detail"))
+	logger.Info("problem response:", recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.String())
+	if recorder.Code != http.StatusInternalServerError || strings.Contains(recorder.Body.String(), "frames") {
+		t.Error("unexpected problem response:", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestWriteProblemOpts(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteProblemOpts(recorder, New("test error detail"), ProblemOptions{Debug: true})
+	logger.Info("problem response:", recorder.Code, recorder.Body.String())
+}
+
+func TestSetProblemOptions(t *testing.T) {
+	SetProblemOptions(ProblemOptions{Instance: "/test"})
+	defer SetProblemOptions(ProblemOptions{})
+	recorder := httptest.NewRecorder()
+	WriteProblem(recorder, New("test error detail"))
+	logger.Info("problem response:", recorder.Body.String())
+}
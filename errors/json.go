@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"strconv"
+)
+
+// jsonErrorDetail is the JSON schema of an ErrorDetail. A wrapped cause that is not an ErrorDetail is represented
+// only by its error text, in the "error" field.
+type jsonErrorDetail struct {
+	Message  string           `json:"message,omitempty"`
+	Public   string           `json:"public_message,omitempty"`
+	File     string           `json:"file,omitempty"`
+	Line     int              `json:"line,omitempty"`
+	Function string           `json:"function,omitempty"`
+	Code     Code             `json:"code,omitempty"`
+	Fields   map[string]any   `json:"fields,omitempty"`
+	Frames   []Frame          `json:"frames,omitempty"`
+	Stack    string           `json:"stack,omitempty"`
+	Cause    *jsonErrorDetail `json:"cause,omitempty"`
+	Error    *string          `json:"error,omitempty"`
+}
+
+// MarshalJSON is a method of the ErrorDetail struct that implements the json.Marshaler interface.
+// It returns a JSON object with the message, public message, file, line, function, code, fields and stack trace
+// frames of the ErrorDetail, without the frames hidden by the package StackFilter, and the wrapped cause as a nested
+// object in the "cause" field, so the whole chain is encoded. Each object has only the fields attached to its own
+// layer, values that can't be encoded are replaced with their text.
+// A cause that is not an ErrorDetail is encoded as an object with its error text in the "error" field, and with the
+// error it wraps in the "cause" field when an ErrorDetail is nested under it, such as in fmt.Errorf("ctx: %w", err).
+// The stack trace text, filtered by the package StackFilter, is encoded in the "stack" field only when it is not the
+// rendering of the frames, such as when the frames are unknown or the stack traces of all goroutines were captured.
+// Example usage:
+//
+//	data, _ := json.Marshal(Wrap(io.EOF, "read body"))
+//	fmt.Println(string(data)) // Output: {"message":"read body","file":"errors/errors_test.go","line":10,
+//	// "function":"TestErrorMarshalJSON","frames":[...],"cause":{"error":"EOF"}}
+func (e *ErrorDetail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// UnmarshalJSON is a method of the ErrorDetail struct that implements the json.Unmarshaler interface.
+// It decodes a JSON object, in the schema produced by MarshalJSON, into the ErrorDetail, reconstructing the whole
+// cause chain. Causes that were not an ErrorDetail are reconstructed as errors with the same error text.
+// Every field of the previous value of the ErrorDetail, including its stack trace, is replaced.
+// It returns an error if the data is not a valid JSON object, in this case the ErrorDetail is not changed.
+func (e *ErrorDetail) UnmarshalJSON(data []byte) error {
+	var value jsonErrorDetail
+	if err := json.Unmarshal(data, &value); helper.IsNotNil(err) {
+		return err
+	}
+	e.replaceWith(value.toErrorDetail())
+	return nil
+}
+
+// ParseJSON is a function that decodes the JSON of an ErrorDetail, as produced by MarshalJSON, into a new
+// ErrorDetail, reconstructing the whole cause chain on the receiving side.
+// It returns an error if the data is not a valid JSON object.
+// Example usage:
+//
+//	errDetail, err := ParseJSON(body)
+//	if err == nil {
+//		errDetail.PrintCause()
+//	}
+func ParseJSON(data []byte) (*ErrorDetail, error) {
+	errDetail := &ErrorDetail{}
+	if err := errDetail.UnmarshalJSON(data); helper.IsNotNil(err) {
+		return nil, err
+	}
+	return errDetail, nil
+}
+
+// toJSON is a method of the ErrorDetail struct that builds the value encoded by MarshalJSON.
+func (e *ErrorDetail) toJSON() *jsonErrorDetail {
+	value := &jsonErrorDetail{
+		Message:  e.message,
+		Public:   e.publicMessage,
+		File:     e.file,
+		Line:     e.GetLine(),
+		Function: e.funcName,
+		Code:     e.code,
+		Fields:   encodableFields(e.fields),
+		Frames:   filterFrames(e.StackTrace()),
+	}
+	if stack := e.GetDebugStack(); len(e.debugStack) != 0 && stack != renderFrames(value.Frames) {
+		value.Stack = stack
+	}
+	if helper.IsNil(e.cause) {
+		return value
+	}
+	value.Cause = causeToJSON(e.cause)
+	return value
+}
+
+// causeToJSON is a function that builds the value encoded by MarshalJSON for a wrapped cause and its whole chain.
+// A cause that is not an ErrorDetail is encoded by its error text, with the error it wraps in its "cause" field when
+// there is an ErrorDetail in the rest of its chain, see unwrapLayer.
+func causeToJSON(cause error) *jsonErrorDetail {
+	if causeDetail, ok := cause.(*ErrorDetail); ok {
+		return causeDetail.toJSON()
+	}
+	next := unwrapLayer(cause)
+	if next == nil {
+		causeText := redactText(cause.Error())
+		return &jsonErrorDetail{Error: &causeText}
+	}
+	causeText := errorMessage(cause)
+	return &jsonErrorDetail{Error: &causeText, Cause: causeToJSON(next)}
+}
+
+// toErrorDetail is a method of the jsonErrorDetail struct that reconstructs the ErrorDetail decoded by UnmarshalJSON.
+func (j *jsonErrorDetail) toErrorDetail() *ErrorDetail {
+	errDetail := &ErrorDetail{
+		file:          j.File,
+		line:          strconv.Itoa(j.Line),
+		funcName:      j.Function,
+		message:       j.Message,
+		publicMessage: j.Public,
+		code:          j.Code,
+		fields:        j.Fields,
+		debugStack:    j.Stack,
+		frames:        j.Frames,
+	}
+	if helper.IsNotNil(j.Cause) {
+		errDetail.cause = j.Cause.toCause()
+	}
+	return errDetail
+}
+
+// toCause is a method of the jsonErrorDetail struct that reconstructs a wrapped cause decoded by UnmarshalJSON, an
+// ErrorDetail, or an error with the same error text, which wraps the rest of the decoded chain if there is one.
+func (j *jsonErrorDetail) toCause() error {
+	if helper.IsNil(j.Error) {
+		return j.toErrorDetail()
+	} else if helper.IsNil(j.Cause) {
+		return errors.New(*j.Error)
+	}
+	return &textError{text: *j.Error, cause: j.Cause.toCause()}
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"testing"
+)
+
+func TestErrorMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Wrap(Wrap(errors.New("sub error"), "test error detail"), "test wrap"))
+	logger.Info("err json:", string(data), err)
+	data, err = json.Marshal(Details(errors.New("test")))
+	logger.Info("err json:", string(data), err)
+}
+
+func TestErrorUnmarshalJSON(t *testing.T) {
+	original := Details(Wrap(Wrap(errors.New("sub error"), "test error detail"), "test wrap"))
+	data, _ := json.Marshal(original)
+	var errDetail ErrorDetail
+	err := json.Unmarshal(data, &errDetail)
+	if helper.IsNotNil(err) {
+		t.Fatal("unmarshal error:", err)
+	}
+	if errDetail.Error() != original.Error() {
+		t.Errorf("unmarshal resulted in %q, expected %q", errDetail.Error(), original.Error())
+	}
+	logger.Info("err unmarshal json:", errDetail.GetCause(), errDetail.StackTrace())
+	err = errDetail.UnmarshalJSON([]byte("test"))
+	logger.Info("err unmarshal json:", err)
+
+	reused := Details(New("test error detail", StackFull))
+	reused.StackTrace()
+	if err = json.Unmarshal([]byte(`{"message":"test"}`), reused); helper.IsNotNil(err) {
+		t.Fatal("unmarshal error:", err)
+	}
+	if len(reused.StackTrace()) != 0 || len(reused.GetDebugStack()) != 0 {
+		t.Errorf("unmarshal json kept the previous stack trace: %q", reused.GetDebugStack())
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	errDetail, err := ParseJSON([]byte(`{"message":"test","file":"main.go","line":10,"function":"main",` +
+		`"cause":{"error":"sub error"}}`))
+	logger.Info("err parse json:", errDetail.GetCause(), errDetail.Unwrap(), err)
+	errDetail, err = ParseJSON([]byte(`[]`))
+	logger.Info("err parse json:", errDetail, err)
+}
+
+func TestErrorJSONForeignLayer(t *testing.T) {
+	inner := Details(NewCode(testCode, "inner")).With("user", 1)
+	err := Wrap(fmt.Errorf("ctx: %w", inner), "outer")
+	data, _ := json.Marshal(err)
+	logger.Info("err json foreign layer:", string(data))
+	parsed, parseErr := ParseJSON(data)
+	if helper.IsNotNil(parseErr) {
+		t.Fatal("parse json error:", parseErr)
+	}
+	if parsed.GetCode() != testCode || parsed.GetFields()["user"] != float64(1) {
+		t.Errorf("parsed code %q and fields %v of the nested layer should be kept", parsed.GetCode(), parsed.GetFields())
+	}
+	layer := parsed.Unwrap()
+	if layer.Error() != "ctx: inner" || Details(errors.Unwrap(layer)).GetLine() != inner.GetLine() {
+		t.Errorf("parsed foreign layer %q should wrap the inner detail", layer.Error())
+	}
+}
@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"net/http"
+)
+
+// HandlerFunc is an adapter to allow the use of functions that return an error as HTTP handlers.
+// If the function returns an error, it is written to the response as a problem with WriteProblem, and it is also
+// printed with the package Printer when its HTTP status is a server error. Panics are recovered the same way
+// RecoverHandler does.
+// Example usage:
+//
+//	http.Handle("/users", HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//		user, err := findUser(r.Context())
+//		if err != nil {
+//			return Wrap(err, "find user")
+//		}
+//		return json.NewEncoder(w).Encode(user)
+//	}))
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// RecoverHandler is a function that creates a middleware that recovers the panics of the `next` handler.
+// The recovered value is converted into an ErrorDetail whose cause location is the function that panicked, which is
+// printed, with its stack trace, using the package Printer, and written to the response as a problem with
+// WriteProblem, unless the handler has already started the response. The http.ErrAbortHandler value is panicked
+// again, to keep its meaning of aborting the response.
+// Example usage:
+//
+//	http.ListenAndServe(":8080", RecoverHandler(mux))
+func RecoverHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &responseWriter{ResponseWriter: w}
+		defer func() {
+			if value := recover(); value != nil {
+				writePanic(writer, r, value)
+			}
+		}()
+		next.ServeHTTP(writer, r)
+	})
+}
+
+// ServeHTTP is a method of the HandlerFunc type that calls the function itself, writing the returned error or the
+// recovered panic to the response as a problem, unless the function has already started the response.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writer := &responseWriter{ResponseWriter: w}
+	defer func() {
+		if value := recover(); value != nil {
+			writePanic(writer, r, value)
+		}
+	}()
+	err := f(writer, r)
+	if helper.IsNil(err) {
+		return
+	}
+	if HTTPStatus(err) >= http.StatusInternalServerError {
+		GetPrinter().Print(1, fmt.Sprintf("%+v", err))
+	}
+	writeProblem(writer, r, err)
+}
+
+// writePanic is a function that converts a value recovered from a panic of an HTTP handler into an ErrorDetail,
+// prints it with the package Printer and writes it to the response as a problem.
+// It must be called by the deferred function that called recover.
+func writePanic(w *responseWriter, r *http.Request, value any) {
+	if value == http.ErrAbortHandler {
+		panic(value)
+	}
+	errDetail := fromPanic(value)
+	GetPrinter().Print(1, fmt.Sprintf("%+v", errDetail))
+	writeProblem(w, r, errDetail)
+}
+
+// writeProblem is a function that writes the error to the response as a problem, using the package ProblemOptions,
+// with the request path as the instance of the problem if none is configured.
+// Nothing is written if the handler has already started the response, whose status can no longer be changed.
+func writeProblem(w *responseWriter, r *http.Request, err error) {
+	if w.started {
+		return
+	}
+	opts := getProblemOptions()
+	if opts.Instance == "" {
+		opts.Instance = r.URL.Path
+	}
+	WriteProblemOpts(w, err, opts)
+}
+
+// responseWriter is an http.ResponseWriter that records whether the handler has started the response, by writing
+// its header or its body.
+type responseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+// WriteHeader is a method of the responseWriter struct that records the start of the response and writes its header.
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write is a method of the responseWriter struct that records the start of the response and writes its body.
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush is a method of the responseWriter struct that implements the http.Flusher interface, if the underlying
+// http.ResponseWriter supports it, recording the start of the response.
+func (w *responseWriter) Flush() {
+	w.started = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap is a method of the responseWriter struct that returns the underlying http.ResponseWriter, used by
+// http.ResponseController to reach its optional interfaces.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
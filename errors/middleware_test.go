@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoverHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	SetPrinter(NewWriterPrinter(&buffer))
+	defer SetPrinter(nil)
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panicTest()
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+	logger.Info("recover handler response:", recorder.Code, recorder.Body.String())
+	logger.Info("recover handler output:", buffer.String())
+	output := buffer.String()
+	if !strings.HasPrefix(output, "(errors/middleware_test.go:") ||
+		!strings.Contains(output, ") panicTest: panic: test panic") {
+		t.Error("cause should be the panicking function:", output)
+	}
+}
+
+func TestRecoverHandlerNilPointer(t *testing.T) {
+	var buffer bytes.Buffer
+	SetPrinter(NewWriterPrinter(&buffer))
+	defer SetPrinter(nil)
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic((*ErrorDetail)(nil))
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+	logger.Info("recover handler nil pointer response:", recorder.Code, recorder.Body.String())
+	if recorder.Code != http.StatusInternalServerError || buffer.Len() == 0 {
+		t.Error("panic with a nil pointer should be recovered:", recorder.Code, buffer.String())
+	}
+}
+
+func TestRecoverHandlerStarted(t *testing.T) {
+	SetPrinter(NewWriterPrinter(io.Discard))
+	defer SetPrinter(nil)
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panicTest()
+	}))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+	logger.Info("recover handler started response:", recorder.Code, recorder.Body.String())
+	if recorder.Code != http.StatusAccepted || recorder.Body.String() != "partial" {
+		t.Error("started response should not be changed:", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestRecoverHandlerAbort(t *testing.T) {
+	handler := RecoverHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		logger.Info("recover handler abort:", recover())
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+}
+
+func TestHandlerFunc(t *testing.T) {
+	var buffer bytes.Buffer
+	SetPrinter(NewWriterPrinter(&buffer))
+	defer SetPrinter(nil)
+	handlers := []HandlerFunc{
+		func(w http.ResponseWriter, r *http.Request) error {
+			return Wrap(errors.New("sub error"), "test error detail")
+		},
+		func(w http.ResponseWriter, r *http.Request) error {
+			panic(errors.New("test panic error"))
+		},
+		func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		},
+	}
+	for _, handler := range handlers {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+		logger.Info("handler func response:", recorder.Code, recorder.Body.String())
+	}
+	logger.Info("handler func output:", buffer.String())
+}
+
+func panicTest() {
+	panic("test panic")
+}
@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"io"
+	"strings"
+	"sync"
+)
+
+// MultiError is an error that aggregates many errors, such as the problems found by a validation, keeping the
+// cause location and stack trace of each ErrorDetail appended to it.
+// It implements the Unwrap() []error method, so Is, As and Details find any of its errors, and it renders as an
+// indented tree by Error and by the %+v verb of Format.
+// The zero value is an empty MultiError ready to use, and it is safe to append to it from multiple goroutines.
+// A MultiError must not be copied after first use.
+type MultiError struct {
+	mutex sync.RWMutex
+	errs  []error
+}
+
+// NewMultiError is a function that returns a new MultiError with the given errors, the nil errors are ignored.
+// Example usage:
+//
+//	multiErr := NewMultiError()
+//	if helper.IsEmpty(user.Name) {
+//		multiErr.Append(New("name is required"))
+//	}
+//	if helper.IsEmpty(user.Email) {
+//		multiErr.Append(New("email is required"))
+//	}
+//	return multiErr.ErrorOrNil()
+func NewMultiError(errs ...error) *MultiError {
+	multiErr := &MultiError{}
+	multiErr.Append(errs...)
+	return multiErr
+}
+
+// Append is a method that adds the given errors to the MultiError, the nil errors are ignored.
+// A MultiError appended is kept as a nested branch of the tree, unless it is the receiver itself or its tree already
+// contains the receiver, such as after a.Append(b), b.Append(a), which are ignored so the tree never has a cycle.
+func (m *MultiError) Append(errs ...error) {
+	var accepted []error
+	for _, err := range errs {
+		if helper.IsNil(err) || m.inTree(err) {
+			continue
+		}
+		accepted = append(accepted, err)
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.errs = append(m.errs, accepted...)
+}
+
+// Len is a method that returns the number of errors of the MultiError.
+func (m *MultiError) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.errs)
+}
+
+// Errors is a method that returns a copy of the errors of the MultiError, in the order in which they were appended.
+func (m *MultiError) Errors() []error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]error(nil), m.errs...)
+}
+
+// ErrorOrNil is a method that returns the MultiError as an error, or nil if it has no errors, avoiding a non-nil
+// error interface holding an empty MultiError.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Unwrap is a method that returns the errors of the MultiError, used by the Is and As functions to examine each one.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Error is a method that returns the errors of the MultiError as an indented tree, each ErrorDetail rendered by its
+// cause location "(file:line) function: message", or only by its message in the production mode.
+// Example usage:
+//
+//	err := NewMultiError(New("name is required"), New("email is required"))
+//	fmt.Println(err.Error())
+//	// Output: 2 errors occurred:
+//	// ├── (user/validate.go:10) validate: name is required
+//	// └── (user/validate.go:13) validate: email is required
+func (m *MultiError) Error() string {
+	var builder strings.Builder
+	m.writeTree(&builder, false)
+	return builder.String()
+}
+
+// Format is a method of the MultiError struct that implements the fmt.Formatter interface.
+// The verbs supported are:
+//
+//	%s    the tree returned by Error
+//	%v    same as %s
+//	%q    the same tree of %s, double-quoted and safely escaped with Go syntax
+//	%+v   the tree with each error printed by its own %+v verb, the ErrorDetail with its full stack trace
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			m.writeTree(s, true)
+			return
+		}
+		_, _ = io.WriteString(s, m.Error())
+	case 's':
+		_, _ = io.WriteString(s, m.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", m.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(*errors.MultiError=%s)", verb, m.Error())
+	}
+}
+
+// writeTree is a method of the MultiError struct that writes the errors as an indented tree to the given writer,
+// rendering each error verbosely if `verbose` is true.
+func (m *MultiError) writeTree(w io.Writer, verbose bool) {
+	errs := m.Errors()
+	if len(errs) == 1 {
+		_, _ = io.WriteString(w, "1 error occurred:")
+	} else {
+		_, _ = fmt.Fprintf(w, "%d errors occurred:", len(errs))
+	}
+	for i, err := range errs {
+		branch, indent := "├── ", "│   "
+		if i == len(errs)-1 {
+			branch, indent = "└── ", "    "
+		}
+		text := strings.TrimRight(treeNodeText(err, verbose), "\n")
+		_, _ = io.WriteString(w, "\n"+branch+strings.ReplaceAll(text, "\n", "\n"+indent))
+	}
+}
+
+// inTree is a method of the MultiError struct that reports whether the receiver is the given error or is in its
+// tree, walked through the Unwrap methods. It is called without holding the lock of the receiver.
+func (m *MultiError) inTree(err error) bool {
+	if err == error(m) {
+		return true
+	}
+	switch t := err.(type) {
+	case interface{ Unwrap() error }:
+		next := t.Unwrap()
+		return next != nil && m.inTree(next)
+	case interface{ Unwrap() []error }:
+		for _, next := range t.Unwrap() {
+			if next != nil && m.inTree(next) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// treeNodeText is a function that returns the text of an error as a node of the MultiError tree.
+func treeNodeText(err error, verbose bool) string {
+	switch t := err.(type) {
+	case *MultiError:
+		var builder strings.Builder
+		t.writeTree(&builder, verbose)
+		return builder.String()
+	case *ErrorDetail:
+		if verbose {
+			return fmt.Sprintf("%+v", t)
+		} else if IsProductionMode() {
+			return t.fullMessage()
+		}
+		return t.GetCause()
+	default:
+		if verbose {
+			return redactText(fmt.Sprintf("%+v", t))
+		}
+		return redactText(t.Error())
+	}
+}
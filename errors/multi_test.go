@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	sentinel := errors.New("test sentinel")
+	multiErr := NewMultiError(New("test error detail"), nil, sentinel)
+	multiErr.Append(NewMultiError(New("test nested 1"), New("test nested 2")), multiErr)
+	if multiErr.Len() != 3 {
+		t.Errorf("multi error should have 3 errors, got %d", multiErr.Len())
+	}
+	if !Is(multiErr, sentinel) {
+		t.Error("multi error should match its errors")
+	}
+	var errDetail *ErrorDetail
+	if !As(multiErr, &errDetail) {
+		t.Error("multi error should find its error details")
+	}
+	logger.Info("multi error:", multiErr.Error())
+	logger.Info(fmt.Sprintf("multi error verbose: %+v", multiErr))
+	logger.Info(fmt.Sprintf("multi error quoted: %q", multiErr))
+	logger.Info(fmt.Sprintf("multi error invalid: %d", multiErr))
+}
+
+func TestMultiErrorAppend(t *testing.T) {
+	var multiErr MultiError
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			multiErr.Append(New("test error detail"))
+		}()
+	}
+	wg.Wait()
+	if len(multiErr.Unwrap()) != 10 {
+		t.Errorf("multi error should have 10 errors, got %d", len(multiErr.Unwrap()))
+	}
+}
+
+func TestMultiErrorCycle(t *testing.T) {
+	a, b := NewMultiError(New("a")), NewMultiError(New("b"))
+	a.Append(b)
+	b.Append(a, Wrap(a, "wrap a"), Join(New("c"), a))
+	a.Append(a)
+	logger.Info("multi error cycle:", a.Error())
+	if b.Len() != 1 || a.Len() != 2 {
+		t.Errorf("errors containing the receiver should be ignored, got %d and %d", a.Len(), b.Len())
+	}
+	if Is(a, New("a")) || !strings.Contains(fmt.Sprintf("%+v", a), "b") {
+		t.Error("multi error should be walked without cycles:", a)
+	}
+}
+
+func TestMultiErrorOrNil(t *testing.T) {
+	var multiErr *MultiError
+	if multiErr.ErrorOrNil() != nil {
+		t.Error("nil multi error should be nil")
+	}
+	if NewMultiError().ErrorOrNil() != nil {
+		t.Error("empty multi error should be nil")
+	}
+	logger.Info("multi error or nil:", NewMultiError(New("test error detail")).ErrorOrNil())
+}
@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/GabrielHCataldo/go-helper/helper"
+)
+
+// Recover is a function that recovers a panic and reports it to the given handler, as an ErrorDetail whose cause
+// location and stack trace start at the function that panicked. If the handler is nil, the error is printed, with
+// its stack trace, using the package Printer.
+// It must be deferred directly, since it calls recover, and it does nothing if the goroutine is not panicking.
+// Example usage:
+//
+//	func worker() {
+//		defer Recover(func(err error) {
+//			slog.Error("worker failed", "err", err)
+//		})
+//		process()
+//	}
+func Recover(handler func(err error)) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	errDetail := fromPanic(value)
+	if handler == nil {
+		GetPrinter().Print(1, fmt.Sprintf("%+v", errDetail))
+		return
+	}
+	handler(errDetail)
+}
+
+// RecoverTo is a function that recovers a panic and assigns it to the error pointed by `err`, as an ErrorDetail
+// whose cause location and stack trace start at the function that panicked, so a function can return the panic as
+// an error. If the recovered value is an error, it is kept as the wrapped cause. If `err` is nil, the error is printed,
+// with its stack trace, using the package Printer, so the panic is never silently discarded.
+// It must be deferred directly, since it calls recover, and it does nothing if the goroutine is not panicking.
+// Example usage:
+//
+//	func parse(data []byte) (result Result, err error) {
+//		defer RecoverTo(&err)
+//		return mustParse(data), nil
+//	}
+func RecoverTo(err *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	errDetail := fromPanic(value)
+	if err == nil {
+		GetPrinter().Print(1, fmt.Sprintf("%+v", errDetail))
+		return
+	}
+	*err = errDetail
+}
+
+// Go is a function that runs the function `fn` in a new goroutine, recovering its panics, so a background worker
+// never crashes the process silently.
+// If `fn` returns an error, or panics, the error is reported to the given handlers, the panic as an ErrorDetail
+// whose cause location is the function that panicked. If no handler is given, the error is printed, with its stack
+// trace, using the package Printer. Nothing is reported when `fn` returns nil.
+// Example usage:
+//
+//	Go(func() error {
+//		return consume(queue)
+//	}, func(err error) {
+//		slog.Error("consumer stopped", "err", err)
+//	})
+func Go(fn func() error, handlers ...func(err error)) {
+	go func() {
+		err := runRecovered(fn)
+		if helper.IsNil(err) {
+			return
+		}
+		if len(handlers) == 0 {
+			GetPrinter().Print(1, fmt.Sprintf("%+v", err))
+		}
+		for _, handler := range handlers {
+			handler(err)
+		}
+	}()
+}
+
+// runRecovered is a function that calls the function `fn`, returning its error, or its panic converted into an
+// ErrorDetail.
+func runRecovered(fn func() error) (err error) {
+	defer RecoverTo(&err)
+	return fn()
+}
+
+// fromPanic is a function that converts a value recovered from a panic into an ErrorDetail whose cause location and
+// stack trace start at the function that panicked, instead of the function that recovered it.
+// It must be called by the deferred function that called recover. If the recovered value is an error, it is kept as
+// the wrapped cause, so it can be found with Is and As.
+func fromPanic(value any) *ErrorDetail {
+	frames := panicFrames(resolveFrames(callers(1)))
+	var file, line, funcName string
+	if len(frames) != 0 {
+		file, line, funcName = frameCallerInfo(frames[0])
+	}
+	errDetail := &ErrorDetail{
+		file:     file,
+		line:     line,
+		funcName: funcName,
+		message:  redactText(fmt.Sprint("panic: ", value)),
+		frames:   frames,
+	}
+	if err, ok := value.(error); ok && helper.IsNotNil(err) {
+		errDetail.message = "panic"
+		errDetail.cause = err
+	}
+	return errDetail
+}
+
+// panicFrames is a function that returns the frames of a stack trace captured while recovering from a panic,
+// starting at the function that panicked, which is the first frame after runtime.gopanic that is not part of the
+// runtime. If the stack trace has no runtime.gopanic frame, the frames are returned as they are.
+func panicFrames(frames []Frame) []Frame {
+	for i, frame := range frames {
+		if frame.Function != "runtime.gopanic" {
+			continue
+		}
+		for j := i + 1; j < len(frames); j++ {
+			if frames[j].Package != "runtime" {
+				return frames[j:]
+			}
+		}
+	}
+	return frames
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	func() {
+		defer Recover(func(err error) {
+			logger.Info("recover:", err)
+			if Details(err).GetFuncName() != "panicTest" {
+				t.Error("cause should be the panicking function:", Details(err).GetCause())
+			}
+		})
+		panicTest()
+	}()
+	func() {
+		defer Recover(nil)
+		panic(errors.New("test panic error"))
+	}()
+	func() {
+		defer Recover(nil)
+	}()
+}
+
+func TestRecoverTo(t *testing.T) {
+	err := recoverToTest(func() {
+		panicTest()
+	})
+	logger.Info("recover to:", err)
+	logger.Info("recover to cause:", Details(err).GetCause())
+	sentinel := errors.New("test panic error")
+	err = recoverToTest(func() {
+		panic(sentinel)
+	})
+	if !Is(err, sentinel) {
+		t.Error("recovered error should wrap the panic value:", err)
+	}
+	logger.Info("recover to:", recoverToTest(func() {}))
+
+	var buffer bytes.Buffer
+	SetPrinter(NewWriterPrinter(&buffer))
+	defer SetPrinter(nil)
+	func() {
+		defer RecoverTo(nil)
+		panicTest()
+	}()
+	if !strings.Contains(buffer.String(), "panic: test panic") {
+		t.Error("panic recovered without error pointer should be printed:", buffer.String())
+	}
+}
+
+func TestGo(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	Go(func() error {
+		panicTest()
+		return nil
+	}, func(err error) {
+		defer wg.Done()
+		logger.Info("go handler:", Details(err).GetCause())
+	})
+	Go(func() error {
+		return New("test error detail")
+	}, func(err error) {
+		defer wg.Done()
+		logger.Info("go handler:", Details(err).GetCause())
+	})
+	wg.Wait()
+}
+
+func TestFrameCallerInfo(t *testing.T) {
+	frame := Frame{Function: "github.com/user/project/pkg.(*Type).Method", File: "/path/to/pkg/file.go", Line: 10}
+	file, line, funcName := frameCallerInfo(frame)
+	logger.Info("frame caller info:", file, line, funcName)
+}
+
+func recoverToTest(fn func()) (err error) {
+	defer RecoverTo(&err)
+	fn()
+	return nil
+}
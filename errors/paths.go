@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PathMode is the mode used to trim the source file paths of the cause location and of the stack trace frames of an
+// ErrorDetail, so the paths don't depend on the directories of the build machine.
+// Example usage:
+//
+//	SetPathMode(PathPrefixes, "/home/runner/work/project/")
+type PathMode int
+
+const (
+	// PathModule trims the paths relative to the root of the main module, detected from the build info, for example
+	// "internal/user/service.go", it is the default mode. The paths outside the main module, and the ones of the main
+	// package, whose import path is not recorded, are trimmed as in the PathGoPath mode, or to the file name with its
+	// directory if they are not in GOROOT or GOPATH either, for example "app/main.go".
+	PathModule PathMode = iota
+	// PathGoPath trims the paths relative to the source directories of GOROOT and GOPATH, including the module
+	// cache, for example "net/http/server.go" or "github.com/user/project@v1.0.0/service.go".
+	PathGoPath
+	// PathPrefixes trims the first matching prefix of the ones given to SetPathMode.
+	PathPrefixes
+	// PathBase trims the paths to the file name only, for example "service.go".
+	PathBase
+	// PathAbsolute keeps the paths as recorded by the Go runtime.
+	PathAbsolute
+)
+
+// pathOptions are the package PathMode and its prefixes.
+var pathOptions struct {
+	mode     PathMode
+	prefixes []string
+}
+
+// pathOptionsMutex guards pathOptions.
+var pathOptionsMutex sync.RWMutex
+
+// mainModulePath is the import path of the main module, read from the build info.
+var mainModulePath = readMainModulePath()
+
+// goRootSourceDir is the source directory of GOROOT, as recorded in the frames of the standard library.
+var goRootSourceDir = readGoRootSourceDir()
+
+// goSourceDirs are the source directories of GOROOT and GOPATH, including the module cache, read when the program
+// starts.
+var goSourceDirs = readGoSourceDirs()
+
+// SetPathMode is a function that sets the package PathMode, applied to the cause location and to every stack trace
+// frame of the errors, with the given prefixes, used only by the PathPrefixes mode.
+func SetPathMode(mode PathMode, prefixes ...string) {
+	pathOptionsMutex.Lock()
+	defer pathOptionsMutex.Unlock()
+	pathOptions.mode = mode
+	pathOptions.prefixes = append([]string(nil), prefixes...)
+}
+
+// GetPathMode is a function that returns the package PathMode.
+func GetPathMode() PathMode {
+	pathOptionsMutex.RLock()
+	defer pathOptionsMutex.RUnlock()
+	return pathOptions.mode
+}
+
+// callerInfo is a function that returns the caller information of the function `skipCaller` frames above it, with
+// the same convention of helper.GetCallerInfo: the file path trimmed by trimPath, the line number and the short
+// function name.
+func callerInfo(skipCaller int) (file string, line string, funcName string) {
+	pc, callerFile, callerLine, ok := runtime.Caller(skipCaller)
+	if !ok {
+		return "", "", ""
+	}
+	var function string
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		function = fn.Name()
+	}
+	return frameCallerInfo(Frame{Function: function, File: trimPath(callerFile, function), Line: callerLine})
+}
+
+// frameCallerInfo is a function that returns the caller information of the given frame, whose file path is already
+// trimmed: the file path, the line number and the short function name.
+func frameCallerInfo(frame Frame) (file string, line string, funcName string) {
+	name := strings.Split(path.Base(frame.Function), ".")
+	return frame.File, strconv.Itoa(frame.Line), name[len(name)-1]
+}
+
+// trimPath is a function that trims the given source file path, of a frame of the given function, according to the
+// package PathMode.
+func trimPath(file, function string) string {
+	pathOptionsMutex.RLock()
+	mode, prefixes := pathOptions.mode, pathOptions.prefixes
+	pathOptionsMutex.RUnlock()
+	switch mode {
+	case PathAbsolute:
+		return file
+	case PathBase:
+		return path.Base(file)
+	case PathPrefixes:
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(file, prefix) {
+				return strings.TrimPrefix(strings.TrimPrefix(file, prefix), "/")
+			}
+		}
+		return file
+	case PathGoPath:
+		if trimmed, ok := trimGoPath(file); ok {
+			return trimmed
+		}
+		return file
+	default:
+		return trimModulePath(file, function)
+	}
+}
+
+// trimModulePath is a function that trims the given source file path relative to the root of the main module, as
+// documented by PathModule.
+func trimModulePath(file, function string) string {
+	dir, base := path.Split(file)
+	dir = strings.TrimSuffix(dir, "/")
+	pkg := strings.TrimSuffix(packageName(function), "_test")
+	if len(mainModulePath) != 0 && (pkg == mainModulePath || strings.HasPrefix(pkg, mainModulePath+"/")) {
+		rel := strings.TrimPrefix(strings.TrimPrefix(pkg, mainModulePath), "/")
+		return path.Join(rel, base)
+	}
+	if trimmed, ok := trimGoPath(file); ok {
+		return trimmed
+	}
+	return path.Join(path.Base(dir), base)
+}
+
+// trimGoPath is a function that trims the given source file path relative to the source directories of GOROOT and
+// GOPATH, returning false if the path is not in any of them.
+func trimGoPath(file string) (string, bool) {
+	for _, dir := range goSourceDirs {
+		if strings.HasPrefix(file, dir+"/") {
+			return strings.TrimPrefix(file, dir+"/"), true
+		}
+	}
+	return file, false
+}
+
+// readGoSourceDirs is a function that returns the source directories of GOROOT and of the directories of GOPATH, or
+// of the default GOPATH if it is not set, with the module cache.
+func readGoSourceDirs() []string {
+	var dirs []string
+	if len(goRootSourceDir) != 0 {
+		dirs = append(dirs, goRootSourceDir)
+	}
+	for _, goPath := range goPaths() {
+		if goPath = filepath.ToSlash(goPath); len(goPath) != 0 {
+			dirs = append(dirs, path.Join(goPath, "pkg", "mod"), path.Join(goPath, "src"))
+		}
+	}
+	return dirs
+}
+
+// readGoRootSourceDir is a function that returns the source directory of GOROOT from the file of a function of the
+// standard library, so it is the directory recorded in the frames, or an empty string if the program was built with
+// the paths trimmed.
+func readGoRootSourceDir() string {
+	fn := runtime.FuncForPC(reflect.ValueOf(strings.Cut).Pointer())
+	if fn == nil {
+		return ""
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	if dir := path.Dir(path.Dir(file)); path.Base(dir) == "src" && len(dir) > len("src") {
+		return dir
+	}
+	return ""
+}
+
+// goPaths is a function that returns the directories of GOPATH, or the default GOPATH if it is not set.
+func goPaths() []string {
+	if goPath := os.Getenv("GOPATH"); len(goPath) != 0 {
+		return filepath.SplitList(goPath)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return []string{filepath.Join(home, "go")}
+	}
+	return nil
+}
+
+// readMainModulePath is a function that returns the import path of the main module from the build info, or an empty
+// string if it is not available.
+func readMainModulePath() string {
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		return buildInfo.Main.Path
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPathMode(t *testing.T) {
+	defer SetPathMode(PathModule)
+	_, file, _, _ := runtime.Caller(0)
+	testCases := []struct {
+		mode     PathMode
+		prefixes []string
+		expected string
+	}{
+		{mode: PathModule, expected: "errors/paths_test.go"},
+		{mode: PathBase, expected: "paths_test.go"},
+		{mode: PathAbsolute, expected: file},
+		{mode: PathPrefixes, prefixes: []string{"/invalid/", filepath.Dir(filepath.Dir(file))}, expected: "errors/paths_test.go"},
+	}
+	for _, testCase := range testCases {
+		SetPathMode(testCase.mode, testCase.prefixes...)
+		errDetail := Details(New("test error detail"))
+		logger.Info("err file:", GetPathMode(), errDetail.GetFile(), errDetail.StackTrace()[0].File)
+		if errDetail.GetFile() != testCase.expected || errDetail.StackTrace()[0].File != testCase.expected {
+			t.Errorf("mode %d: expected file %s, got %s", testCase.mode, testCase.expected, errDetail.GetFile())
+		}
+	}
+}
+
+func TestPathModeGoPath(t *testing.T) {
+	defer SetPathMode(PathModule)
+	SetPathMode(PathGoPath)
+	stackTrace := Details(New("test error detail")).StackTrace()
+	last := stackTrace[len(stackTrace)-1]
+	logger.Info("err frame file:", last.File)
+	if last.File != "testing/testing.go" {
+		t.Error("standard library frames should be relative to GOROOT:", last.File)
+	}
+	if !strings.HasPrefix(trimPath("/other/dir/file.go", "main.main"), "/other") {
+		t.Error("paths outside GOROOT and GOPATH should be kept")
+	}
+}
+
+func TestTrimModulePath(t *testing.T) {
+	testCases := [][3]string{
+		{"/build/errors/file_test.go", packagePath + "_test.TestFile", "errors/file_test.go"},
+		{goRootSourceDir + "/testing/testing.go", "testing.tRunner", "testing/testing.go"},
+		{"/other/dir/file.go", "main.main", "dir/file.go"},
+		{"/build/_example/main.go", "main.main", "_example/main.go"},
+		{"errors/errors.go", packagePath + ".New", "errors/errors.go"},
+		{"/build/cmd/app/file.go", mainModulePath + "/cmd/app.Run", "cmd/app/file.go"},
+		{"/build/cmd/app/main.go", "main.main", "app/main.go"},
+	}
+	for _, testCase := range testCases {
+		if trimmed := trimModulePath(testCase[0], testCase[1]); trimmed != testCase[2] {
+			t.Errorf("expected %s, got %s", testCase[2], trimmed)
+		}
+	}
+}
+
+func TestTrimStackPaths(t *testing.T) {
+	stack := "goroutine 1 [running]:\nmain.(*server).run(...)\n\t/build/cmd/app/main.go:10\n" +
+		"created by main.main in goroutine 1\n\t/build/cmd/app/main.go:5\n\n" +
+		"goroutine 2 [select]:\nnet/http.(*Server).Serve(...)\n\t" + goRootSourceDir + "/net/http/server.go:3000\n"
+	trimmed := trimStackPaths(stack)
+	logger.Info("trimmed stack:", trimmed)
+	expected := "goroutine 1 [running]:\nmain.(*server).run(...)\n\tapp/main.go:10\n" +
+		"created by main.main in goroutine 1\n\tapp/main.go:5\n\n" +
+		"goroutine 2 [select]:\nnet/http.(*Server).Serve(...)\n\tnet/http/server.go:3000\n"
+	if trimmed != expected {
+		t.Errorf("expected %q, got %q", expected, trimmed)
+	}
+	_, file, _, _ := runtime.Caller(0)
+	debugStack := Details(New("test error detail", StackAllGoroutines)).GetDebugStack()
+	if strings.Contains(debugStack, file) {
+		t.Error("all goroutines stack paths should be trimmed:", debugStack)
+	}
+}
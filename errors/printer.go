@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"context"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"io"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Printer is the output sink used by the print methods of ErrorDetail, such as PrintStackTrace and PrintCause.
+type Printer interface {
+	// Print writes the given text. The skipCaller parameter is the number of stack frames to ascend to find the
+	// function the output should be attributed to, with 1 identifying the caller of Print, the same convention
+	// of logger.ErrorSkipCaller.
+	Print(skipCaller int, text string)
+}
+
+// PrinterFunc is an adapter to allow the use of ordinary functions as a Printer. The skipCaller received by the
+// function follows the same convention of Print, counting the function itself as the implementation of Print.
+type PrinterFunc func(skipCaller int, text string)
+
+// loggerPrinter is the Printer that logs with go-logger.
+type loggerPrinter struct{}
+
+// writerPrinter is the Printer that writes to an io.Writer.
+type writerPrinter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// slogPrinter is the Printer that logs with a slog.Logger.
+type slogPrinter struct {
+	logger *slog.Logger
+}
+
+// printer is the package Printer, used by the print methods of ErrorDetail.
+var printer Printer = NewLoggerPrinter()
+
+// printerMutex guards the package Printer.
+var printerMutex sync.RWMutex
+
+// SetPrinter is a function that sets the package Printer, used by the print methods of ErrorDetail, such as
+// PrintStackTrace and PrintCause, so they print where the application logs go.
+// If the given Printer is nil, the default Printer, created by NewLoggerPrinter, is restored.
+// Example usage:
+//
+//	SetPrinter(NewSlogPrinter(slog.Default()))
+//	Details(New("test error detail")).PrintCause()
+func SetPrinter(p Printer) {
+	if helper.IsNil(p) {
+		p = NewLoggerPrinter()
+	}
+	printerMutex.Lock()
+	defer printerMutex.Unlock()
+	printer = p
+}
+
+// GetPrinter is a function that returns the package Printer, set by SetPrinter.
+func GetPrinter() Printer {
+	printerMutex.RLock()
+	defer printerMutex.RUnlock()
+	return printer
+}
+
+// NewLoggerPrinter is a function that creates a Printer that logs the text with logger.ErrorSkipCaller, from
+// go-logger, attributing it to the right caller. It is the default package Printer.
+func NewLoggerPrinter() Printer {
+	return loggerPrinter{}
+}
+
+// NewWriterPrinter is a function that creates a Printer that writes the text followed by a newline to the given
+// io.Writer, serializing concurrent writes. It can be used to print to a file, to the standard error or to capture
+// the output in tests.
+// Example usage:
+//
+//	var buffer bytes.Buffer
+//	Details(New("test error detail")).PrintCauseTo(NewWriterPrinter(&buffer))
+func NewWriterPrinter(w io.Writer) Printer {
+	return &writerPrinter{writer: w}
+}
+
+// NewSlogPrinter is a function that creates a Printer that logs the text with the given slog.Logger, at the error
+// level, with the source of the record pointing to the right caller.
+// If the given logger is nil, slog.Default is used when printing.
+func NewSlogPrinter(l *slog.Logger) Printer {
+	return slogPrinter{logger: l}
+}
+
+// Print is a method of the PrinterFunc type that calls the function itself.
+func (f PrinterFunc) Print(skipCaller int, text string) {
+	f(skipCaller+1, text)
+}
+
+// Print is a method of the loggerPrinter struct that logs the text with logger.ErrorSkipCaller.
+func (p loggerPrinter) Print(skipCaller int, text string) {
+	logger.ErrorSkipCaller(skipCaller+1, text)
+}
+
+// Print is a method of the writerPrinter struct that writes the text followed by a newline to the io.Writer.
+func (p *writerPrinter) Print(_ int, text string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, _ = io.WriteString(p.writer, text+"\n")
+}
+
+// Print is a method of the slogPrinter struct that logs the text with the slog.Logger, at the error level.
+func (p slogPrinter) Print(skipCaller int, text string) {
+	l := p.logger
+	if helper.IsNil(l) {
+		l = slog.Default()
+	}
+	ctx := context.Background()
+	if !l.Enabled(ctx, slog.LevelError) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(skipCaller+1, pcs[:])
+	record := slog.NewRecord(time.Now(), slog.LevelError, text, pcs[0])
+	_ = l.Handler().Handle(ctx, record)
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"bytes"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetPrinter(t *testing.T) {
+	var buffer bytes.Buffer
+	SetPrinter(NewWriterPrinter(&buffer))
+	defer SetPrinter(nil)
+	errDetail := Details(New("test error detail"))
+	errDetail.PrintCause()
+	errDetail.PrintStackTrace()
+	logger.Info("printer output:", buffer.String())
+	if !strings.HasPrefix(buffer.String(), errDetail.GetCause()+"\n") {
+		t.Error("cause was not printed to the writer:", buffer.String())
+	}
+}
+
+func TestErrorPrintCauseTo(t *testing.T) {
+	var buffer bytes.Buffer
+	slogger := slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{AddSource: true}))
+	Details(New("test error detail")).PrintCauseTo(NewSlogPrinter(slogger))
+	logger.Info("printer output:", buffer.String())
+	if !strings.Contains(buffer.String(), "printer_test.go") {
+		t.Error("source should be the caller of PrintCauseTo:", buffer.String())
+	}
+	Details(New("test error detail")).PrintCauseTo(NewSlogPrinter(nil))
+}
+
+func TestErrorPrintStackTraceTo(t *testing.T) {
+	Details(New("test error detail")).PrintStackTraceTo(NewLoggerPrinter())
+	Details(New("test error detail")).PrintStackTraceTo(PrinterFunc(func(skipCaller int, text string) {
+		logger.InfoSkipCaller(skipCaller+1, "printer func:", text)
+	}))
+}
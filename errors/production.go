@@ -0,0 +1,29 @@
+package errors
+
+import "sync/atomic"
+
+// production reports whether the production mode is enabled.
+var production atomic.Bool
+
+func init() {
+	production.Store(productionBuild)
+}
+
+// SetProductionMode is a function that enables or disables the production mode, which is disabled by default, or
+// enabled by default when the program is built with the build tag "goerrors_production".
+// In the production mode, the Error method of ErrorDetail and MultiError returns only a safe summary, the messages
+// of the chain, without file paths and stack traces, so the text of an error can reach a client or a third-party log
+// without leaking internals. The full detail remains available through GetDebugStack, the %+v verb, MarshalText,
+// MarshalJSON and LogValue.
+// Example usage:
+//
+//	SetProductionMode(os.Getenv("ENV") == "production")
+//	fmt.Println(Wrap(io.EOF, "read body").Error()) // Output: read body: EOF
+func SetProductionMode(enabled bool) {
+	production.Store(enabled)
+}
+
+// IsProductionMode is a function that reports whether the production mode is enabled, see SetProductionMode.
+func IsProductionMode() bool {
+	return production.Load()
+}
@@ -0,0 +1,6 @@
+//go:build goerrors_production
+
+package errors
+
+// productionBuild is the initial state of the production mode, enabled by the build tag "goerrors_production".
+const productionBuild = true
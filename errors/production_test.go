@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"testing"
+)
+
+func TestProductionMode(t *testing.T) {
+	defer SetProductionMode(IsProductionMode())
+	SetProductionMode(true)
+	err := Wrap(errors.New("sub error"), "test error detail")
+	logger.Info("err:", err.Error())
+	if err.Error() != "test error detail: sub error" || !IsProductionMode() {
+		t.Error("production error should be a safe summary:", err.Error())
+	}
+	text, _ := Details(err).MarshalText()
+	if _, parseErr := Parse(string(text)); parseErr != nil {
+		t.Error("production text should be parsed:", parseErr)
+	}
+	data, _ := json.Marshal(err)
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.Contains(string(data), "frames") || !strings.Contains(verbose, "production_test.go") ||
+		len(Details(err).GetDebugStack()) == 0 {
+		t.Error("production error should keep the full detail:", verbose)
+	}
+	multiErr := NewMultiError(err, New("test"))
+	logger.Info("multi error:", multiErr.Error())
+	if strings.Contains(multiErr.Error(), "production_test.go") {
+		t.Error("production multi error should be a safe summary:", multiErr.Error())
+	}
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"errors"
+	"github.com/GabrielHCataldo/go-helper/helper"
+	"sync"
+)
+
+// defaultPublicMessage is the public message returned by GetPublicMessage when the chain has none.
+var defaultPublicMessage = "An unexpected error occurred."
+
+// defaultPublicMessageMutex guards defaultPublicMessage.
+var defaultPublicMessageMutex sync.RWMutex
+
+// NewPublic is a function that creates a new error with additional error details, carrying a public message, safe
+// to be shown to end users, and an internal message, with the diagnostic details for engineers.
+// It takes in the public message and variadic arguments `args` of any type and builds the internal message using
+// `buildMessage`, the same way New does. If the internal message is empty, it is the public message.
+// The internal message is the one returned by GetMessage, Error and the other outputs of the error, the public
+// message is returned by GetPublicMessage and is the only one written by the HTTP problem encoders. Both messages
+// have the sensitive values replaced by the package Redactor, see SetRedactors.
+// Example usage:
+//
+//	err := NewPublic("The user was not found.", "find user", userID, sql.ErrNoRows)
+//	fmt.Println(Details(err).GetMessage()) // Output: find user 10 sql: no rows in result set
+//	fmt.Println(Details(err).GetPublicMessage()) // Output: The user was not found.
+func NewPublic(public string, args ...any) error {
+	public = redactText(public)
+	msg := buildMessage(args...)
+	if msg == "" {
+		msg = public
+	}
+	errDetail := newDetail(1, nil, msg, args)
+	errDetail.publicMessage = public
+	return errDetail
+}
+
+// WrapPublic is a function that creates a new error with additional error details, keeping `err` as its cause, the
+// same way Wrap does, carrying a public message, safe to be shown to end users, and an internal message built from
+// the variadic arguments `args`. If the internal message is empty, it is the public message.
+// If `err` is nil, it returns nil.
+// Example usage:
+//
+//	if err := repository.Save(ctx, user); err != nil {
+//		return WrapPublic(err, "The user could not be saved, try again later.", "save user")
+//	}
+func WrapPublic(err error, public string, args ...any) error {
+	if helper.IsNil(err) {
+		return nil
+	}
+	public = redactText(public)
+	msg := buildMessage(args...)
+	if msg == "" {
+		msg = public
+	}
+	errDetail := newDetail(1, err, msg, args)
+	errDetail.publicMessage = public
+	return errDetail
+}
+
+// SetDefaultPublicMessage is a function that sets the public message returned by GetPublicMessage, and written by
+// the HTTP problem encoders, for errors whose chain has no public message.
+// Example usage:
+//
+//	SetDefaultPublicMessage("Something went wrong, please try again later.")
+func SetDefaultPublicMessage(message string) {
+	defaultPublicMessageMutex.Lock()
+	defer defaultPublicMessageMutex.Unlock()
+	defaultPublicMessage = message
+}
+
+// GetDefaultPublicMessage is a function that returns the public message used for errors whose chain has none, see
+// SetDefaultPublicMessage.
+func GetDefaultPublicMessage() string {
+	defaultPublicMessageMutex.RLock()
+	defer defaultPublicMessageMutex.RUnlock()
+	return defaultPublicMessage
+}
+
+// GetPublicMessage is a method of the ErrorDetail struct that returns the public message of the outermost layer of
+// the chain that has one, walking through the wrapped causes, or the default public message, set by
+// SetDefaultPublicMessage, if there is none.
+// Example usage:
+//
+//	err := Wrap(NewPublic("The user was not found.", "find user"), "get profile")
+//	fmt.Println(Details(err).GetPublicMessage()) // Output: The user was not found.
+func (e *ErrorDetail) GetPublicMessage() string {
+	if e.publicMessage != "" {
+		return e.publicMessage
+	}
+	var causeDetail *ErrorDetail
+	if errors.As(e.cause, &causeDetail) {
+		return causeDetail.GetPublicMessage()
+	}
+	return GetDefaultPublicMessage()
+}
+
+// publicMessage is a function that returns the public message of the first ErrorDetail in the chain of `err`, or the
+// default public message if there is none.
+func publicMessage(err error) string {
+	var errDetail *ErrorDetail
+	if errors.As(err, &errDetail) {
+		return errDetail.GetPublicMessage()
+	}
+	return GetDefaultPublicMessage()
+}
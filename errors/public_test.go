@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewPublic(t *testing.T) {
+	err := NewPublic("The user was not found.", "find user", 10, errors.New("sub error"))
+	logger.Info("err:", err)
+	if Details(err).GetMessage() != "find user 10 sub error" ||
+		Details(err).GetPublicMessage() != "The user was not found." {
+		t.Error("public and internal messages should be kept:", Details(err).GetMessage())
+	}
+	if Details(NewPublic("The user was not found.")).GetMessage() != "The user was not found." {
+		t.Error("internal message should default to the public message")
+	}
+}
+
+func TestWrapPublic(t *testing.T) {
+	err := Wrap(WrapPublic(errors.New("sub error"), "The user could not be saved.", "save user"), "test wrap")
+	logger.Info("err:", err)
+	if Details(err).GetPublicMessage() != "The user could not be saved." {
+		t.Error("public message should be found in the chain:", Details(err).GetPublicMessage())
+	}
+	if WrapPublic(nil, "test") != nil {
+		t.Error("wrap of nil should be nil")
+	}
+}
+
+func TestGetPublicMessage(t *testing.T) {
+	err := WrapPublic(NewPublic("inner public", "test"), "outer public", "test wrap")
+	if Details(err).GetPublicMessage() != "outer public" {
+		t.Error("outermost public message should be returned:", Details(err).GetPublicMessage())
+	}
+	SetDefaultPublicMessage("test default")
+	defer SetDefaultPublicMessage("An unexpected error occurred.")
+	if Details(New("test error detail")).GetPublicMessage() != "test default" {
+		t.Error("default public message should be returned")
+	}
+	logger.Info("default public message:", GetDefaultPublicMessage())
+}
+
+func TestPublicEncoding(t *testing.T) {
+	err := Wrap(NewPublic("The [user] was not found.", "find user"), "test wrap")
+	parsed, parseErr := Parse(textOf(err))
+	if parseErr != nil || parsed.GetPublicMessage() != "The [user] was not found." {
+		t.Error("public message should be parsed back:", parseErr)
+	}
+	data, _ := json.Marshal(err)
+	logger.Info("err json:", string(data))
+	parsedJSON, _ := ParseJSON(data)
+	if parsedJSON.GetPublicMessage() != "The [user] was not found." {
+		t.Error("public message should be decoded back")
+	}
+}
+
+func TestPublicProblem(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	WriteProblem(recorder, Wrap(NewPublic("The user was not found.", "secret internal detail"), "test wrap"))
+	logger.Info("problem response:", recorder.Body.String())
+	if strings.Contains(recorder.Body.String(), "secret") ||
+		!strings.Contains(recorder.Body.String(), "The user was not found.") {
+		t.Error("problem should have only the public message")
+	}
+	problem := NewProblem(errors.New("secret internal detail"), ProblemOptions{})
+	if problem.Detail != GetDefaultPublicMessage() {
+		t.Error("problem of a plain error should have the default public message:", problem.Detail)
+	}
+}
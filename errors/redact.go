@@ -0,0 +1,171 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactedText is the text that replaces the sensitive values in the outputs of an error.
+const RedactedText = "[REDACTED]"
+
+// Redactor replaces the sensitive values found in a text, such as emails, tokens and card numbers.
+type Redactor interface {
+	// Redact returns the given text with the sensitive values replaced.
+	Redact(text string) string
+}
+
+// RedactorFunc is an adapter to allow the use of ordinary functions as a Redactor.
+type RedactorFunc func(text string) string
+
+// Secret is a wrapper of a sensitive value, such as a password or a token, passed as an argument to the
+// constructors, such as New, or as the value of a field, which is always written as RedactedText, in every output
+// of the error: the message, Error, the fmt verbs, JSON and slog.
+type Secret struct {
+	value any
+}
+
+// regexRedactor is the Redactor that replaces the matches of a regular expression.
+type regexRedactor struct {
+	regex       *regexp.Regexp
+	replacement string
+	valid       func(match string) bool
+}
+
+var (
+	// EmailRedactor is the Redactor of email addresses.
+	EmailRedactor Redactor = NewRegexRedactor(regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "")
+	// JWTRedactor is the Redactor of JSON Web Tokens, in the compact serialization "header.payload.signature".
+	JWTRedactor Redactor = NewRegexRedactor(regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), "")
+	// PANRedactor is the Redactor of payment card numbers (PAN), from 13 to 19 digits, optionally grouped by spaces
+	// or dashes, which pass the Luhn checksum, so other long numbers, such as IDs, are usually kept.
+	PANRedactor Redactor = &regexRedactor{
+		regex:       regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		replacement: RedactedText,
+		valid:       luhnValid,
+	}
+)
+
+// redactors are the package Redactor, applied to the messages and fields of the errors.
+var redactors []Redactor
+
+// redactorsMutex guards the package Redactor.
+var redactorsMutex sync.RWMutex
+
+// Redacted is a function that wraps the given sensitive value in a Secret, so it is written as RedactedText in every
+// output of the error.
+// Example usage:
+//
+//	err := New("login failed for", Redacted(password))
+//	fmt.Println(Details(err).GetMessage()) // Output: login failed for [REDACTED]
+func Redacted(value any) Secret {
+	return Secret{value: value}
+}
+
+// Value is a method of the Secret struct that returns the wrapped sensitive value.
+func (s Secret) Value() any {
+	return s.value
+}
+
+// String is a method of the Secret struct that implements the fmt.Stringer interface, returning RedactedText.
+func (s Secret) String() string {
+	return RedactedText
+}
+
+// Format is a method of the Secret struct that implements the fmt.Formatter interface, writing RedactedText for
+// every verb, including %#v, so the wrapped value is never printed by the fmt functions.
+func (s Secret) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(RedactedText))
+}
+
+// MarshalJSON is a method of the Secret struct that implements the json.Marshaler interface, returning RedactedText
+// as a JSON string.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + RedactedText + `"`), nil
+}
+
+// LogValue is a method of the Secret struct that implements the slog.LogValuer interface, returning RedactedText.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(RedactedText)
+}
+
+// Redact is a method of the RedactorFunc type that calls the function with the given text.
+func (f RedactorFunc) Redact(text string) string {
+	return f(text)
+}
+
+// NewRegexRedactor is a function that creates a new Redactor that replaces every match of the given regular
+// expression with the replacement, or with RedactedText if the replacement is empty.
+// Example usage:
+//
+//	SetRedactors(EmailRedactor, NewRegexRedactor(regexp.MustCompile(`sk_live_\w+`), ""))
+func NewRegexRedactor(regex *regexp.Regexp, replacement string) Redactor {
+	if len(replacement) == 0 {
+		replacement = RedactedText
+	}
+	return &regexRedactor{regex: regex, replacement: replacement}
+}
+
+// Redact is a method of the regexRedactor struct that replaces the matches of the regular expression in the text.
+func (r *regexRedactor) Redact(text string) string {
+	return r.regex.ReplaceAllStringFunc(text, func(match string) string {
+		if r.valid != nil && !r.valid(match) {
+			return match
+		}
+		return r.replacement
+	})
+}
+
+// SetRedactors is a function that sets the package Redactor, replacing the previous ones, which are applied, in the
+// given order, to the messages built by the constructors, to the text of the wrapped errors that are not an
+// ErrorDetail and to the string values of the fields, so the sensitive values are redacted in every output of the
+// error. By default, there are no redactors, calling it without arguments removes them.
+// Example usage:
+//
+//	SetRedactors(EmailRedactor, JWTRedactor, PANRedactor)
+//	err := New("invalid card 4111 1111 1111 1111 for user@example.com")
+//	fmt.Println(Details(err).GetMessage()) // Output: invalid card [REDACTED] for [REDACTED]
+func SetRedactors(rs ...Redactor) {
+	redactorsMutex.Lock()
+	defer redactorsMutex.Unlock()
+	redactors = append([]Redactor(nil), rs...)
+}
+
+// redactText is a function that applies the package Redactor to the given text.
+func redactText(text string) string {
+	redactorsMutex.RLock()
+	defer redactorsMutex.RUnlock()
+	for _, redactor := range redactors {
+		text = redactor.Redact(text)
+	}
+	return text
+}
+
+// redactValue is a function that applies the package Redactor to the given field value if it is a string, other
+// values are returned as they are.
+func redactValue(value any) any {
+	if text, ok := value.(string); ok {
+		return redactText(text)
+	}
+	return value
+}
+
+// luhnValid is a function that checks if the digits of the given text pass the Luhn checksum, ignoring spaces and
+// dashes.
+func luhnValid(text string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(text)
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
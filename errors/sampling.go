@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StackSampling are the options of the stack sampling, which limits the full stack traces captured for errors
+// created repeatedly at the same call site, such as in hot paths, where capturing a stack for each error would
+// dominate the CPU usage.
+// When it is enabled, only the first Limit errors created at each call site, identified by its program counter, in
+// each time Window have the full stack trace captured, the next ones have only the frame of the caller captured, as
+// in the StackCaller mode, until the window ends.
+// The sampling applies to the package StackMode set to StackFull or StackAllGoroutines, a StackMode passed as the
+// last argument of a constructor is always honored.
+type StackSampling struct {
+	// Limit is the number of full stack traces captured per call site in each window. If it is zero or negative, the
+	// sampling is disabled and every error has its stack trace captured.
+	Limit int
+	// Window is the duration of the time window in which the stack traces are counted per call site. If it is zero
+	// or negative, the window never ends, so only the first Limit errors of each call site have a full stack trace.
+	Window time.Duration
+}
+
+// StackSamplingStats are the counters of the stack sampling, since it was set by SetStackSampling.
+type StackSamplingStats struct {
+	// Captured is the number of full stack traces captured while the sampling was enabled.
+	Captured uint64
+	// Skipped is the number of full stack traces skipped by the sampling, replaced with the caller frame only.
+	Skipped uint64
+}
+
+// stackSite is the state of the stack sampling of a call site, updated without locking by the errors created there.
+type stackSite struct {
+	windowStart atomic.Int64
+	count       atomic.Int64
+}
+
+// stackSampling are the package StackSampling options, nil until SetStackSampling is called.
+var stackSampling atomic.Pointer[StackSampling]
+
+// stackSites are the states of the stack sampling, *stackSite, by call site program counter.
+var stackSites atomic.Pointer[sync.Map]
+
+// stackSamplingEnabled reports whether the package StackSampling has a limit, read on every error creation.
+var stackSamplingEnabled atomic.Bool
+
+// stackSamplingCaptured and stackSamplingSkipped are the counters returned by GetStackSamplingStats.
+var stackSamplingCaptured, stackSamplingSkipped atomic.Uint64
+
+// SetStackSampling is a function that sets the package StackSampling options, resetting the occurrences counted per
+// call site and the counters returned by GetStackSamplingStats. The zero value disables the sampling.
+// Example usage:
+//
+//	SetStackSampling(StackSampling{Limit: 10, Window: time.Minute})
+func SetStackSampling(sampling StackSampling) {
+	stackSites.Store(&sync.Map{})
+	stackSampling.Store(&sampling)
+	stackSamplingEnabled.Store(sampling.Limit > 0)
+	stackSamplingCaptured.Store(0)
+	stackSamplingSkipped.Store(0)
+}
+
+// GetStackSamplingStats is a function that returns the counters of the full stack traces captured and skipped by the
+// stack sampling.
+// Example usage:
+//
+//	stats := GetStackSamplingStats()
+//	metrics.Gauge("errors.stacks.skipped", stats.Skipped)
+func GetStackSamplingStats() StackSamplingStats {
+	return StackSamplingStats{
+		Captured: stackSamplingCaptured.Load(),
+		Skipped:  stackSamplingSkipped.Load(),
+	}
+}
+
+// sampleStack is a function that reports whether a full stack trace can be captured for an error created at the
+// call site with the given program counter, counting the occurrence.
+// The call sites are counted with atomic operations only, so the errors created concurrently at the same call site
+// when its window ends may be counted in the previous window.
+func sampleStack(pc uintptr) bool {
+	sampling, sites := stackSampling.Load(), stackSites.Load()
+	if sampling == nil || sampling.Limit <= 0 || sites == nil {
+		return true
+	}
+	value, ok := sites.Load(pc)
+	if !ok {
+		site := &stackSite{}
+		site.windowStart.Store(time.Now().UnixNano())
+		value, _ = sites.LoadOrStore(pc, site)
+	}
+	site := value.(*stackSite)
+	if window := int64(sampling.Window); window > 0 {
+		now, windowStart := time.Now().UnixNano(), site.windowStart.Load()
+		if now-windowStart >= window && site.windowStart.CompareAndSwap(windowStart, now) {
+			site.count.Store(0)
+		}
+	}
+	if site.count.Add(1) > int64(sampling.Limit) {
+		stackSamplingSkipped.Add(1)
+		return false
+	}
+	stackSamplingCaptured.Add(1)
+	return true
+}
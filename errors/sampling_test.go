@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"errors"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"testing"
+	"time"
+)
+
+func TestStackSampling(t *testing.T) {
+	SetStackSampling(StackSampling{Limit: 2, Window: time.Hour})
+	defer SetStackSampling(StackSampling{})
+	var stackSizes []int
+	for i := 0; i < 5; i++ {
+		stackSizes = append(stackSizes, len(Details(New("test error detail")).StackTrace()))
+	}
+	logger.Info("stack sizes:", stackSizes)
+	if stackSizes[1] <= 1 || stackSizes[2] != 1 || stackSizes[4] != 1 {
+		t.Error("only the first 2 stacks should be captured:", stackSizes)
+	}
+	if len(Details(New("test error detail", StackFull)).StackTrace()) <= 1 {
+		t.Error("stack mode argument should not be sampled")
+	}
+	if len(Details(errors.New("test")).StackTrace()) <= 1 {
+		t.Error("details of an error without stack should not be sampled")
+	}
+	stats := GetStackSamplingStats()
+	logger.Info("stack sampling stats:", stats)
+	if stats.Captured != 2 || stats.Skipped != 3 {
+		t.Error("stack sampling stats should count the captured and skipped stacks:", stats)
+	}
+}
+
+func TestStackSamplingWindow(t *testing.T) {
+	SetStackSampling(StackSampling{Limit: 1, Window: 100 * time.Millisecond})
+	defer SetStackSampling(StackSampling{})
+	var stackSizes []int
+	for i := 0; i < 3; i++ {
+		stackSizes = append(stackSizes, len(Details(New("test error detail")).StackTrace()))
+		if i == 1 {
+			time.Sleep(150 * time.Millisecond)
+		}
+	}
+	logger.Info("stack sizes:", stackSizes)
+	if stackSizes[0] <= 1 || stackSizes[1] != 1 || stackSizes[2] <= 1 {
+		t.Error("stack should be captured again in a new window:", stackSizes)
+	}
+}
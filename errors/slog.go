@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that expands the errors found in the attributes of a record, wherever they are,
+// including inside groups, into the structured group returned by ErrorDetail.LogValue, before passing the record to
+// the next handler. Attributes with errors whose chain has no ErrorDetail are passed as they are.
+type SlogHandler struct {
+	next slog.Handler
+}
+
+// NewSlogHandler is a function that creates a new SlogHandler wrapping the `next` handler, which receives the records
+// with the errors expanded.
+// Example usage:
+//
+//	logger := slog.New(NewSlogHandler(slog.NewJSONHandler(os.Stderr, nil)))
+//	logger.Error("request failed", "err", fmt.Errorf("handler: %w", New("test error detail")))
+//	// Output: {"time":"...","level":"ERROR","msg":"request failed","err":{"msg":"handler: test error detail",
+//	// "file":"main.go","line":10,"func":"main","stack":"..."}}
+func NewSlogHandler(next slog.Handler) *SlogHandler {
+	return &SlogHandler{next: next}
+}
+
+// LogValue is a method of the ErrorDetail struct that implements the slog.LogValuer interface.
+// It returns a group with the message followed by the messages of the wrapped causes ("msg"), the file ("file"),
+// the line number ("line"), the function name ("func"), the code returned by GetCode ("code") and the fields returned
+// by GetFields ("fields"), only if there are any, and the stack trace ("stack") of the error, so slog handlers log
+// the ErrorDetail as structured fields.
+// Example usage:
+//
+//	slog.Error("request failed", "err", New("test error detail"))
+func (e *ErrorDetail) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", e.fullMessage()),
+		slog.String("file", e.file),
+		slog.Int("line", e.GetLine()),
+		slog.String("func", e.funcName),
+	}
+	if code := e.GetCode(); code != "" {
+		attrs = append(attrs, slog.String("code", string(code)))
+	}
+	if fields := e.GetFields(); len(fields) != 0 {
+		fieldAttrs := make([]any, 0, len(fields))
+		for _, key := range sortedFieldKeys(fields) {
+			fieldAttrs = append(fieldAttrs, slog.Any(key, fields[key]))
+		}
+		attrs = append(attrs, slog.Group("fields", fieldAttrs...))
+	}
+	attrs = append(attrs, slog.String("stack", e.GetDebugStack()))
+	return slog.GroupValue(attrs...)
+}
+
+// Enabled is a method of the SlogHandler struct that reports whether the next handler handles records at the given
+// level.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle is a method of the SlogHandler struct that expands the errors found in the attributes of the record and
+// passes the resulting record to the next handler.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(expandSlogAttr(attr))
+		return true
+	})
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs is a method of the SlogHandler struct that returns a new SlogHandler whose next handler has the given
+// attributes, with the errors found in them expanded.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		expanded[i] = expandSlogAttr(attr)
+	}
+	return &SlogHandler{next: h.next.WithAttrs(expanded)}
+}
+
+// WithGroup is a method of the SlogHandler struct that returns a new SlogHandler whose next handler has the given
+// group.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{next: h.next.WithGroup(name)}
+}
+
+// expandSlogAttr is a function that replaces the value of an attribute holding an error that has an ErrorDetail in
+// its chain with the group returned by ErrorDetail.LogValue, whose message is the one of the whole error, recursively
+// for the attributes of groups.
+func expandSlogAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	switch attr.Value.Kind() {
+	case slog.KindAny:
+		err, ok := attr.Value.Any().(error)
+		var errDetail *ErrorDetail
+		if ok && errors.As(err, &errDetail) {
+			attr.Value = errDetail.LogValue()
+			if err != error(errDetail) {
+				// the message of the outer error replaces the "msg" attribute, the first one of the group, so the
+				// context added by the errors that wrap the ErrorDetail is kept
+				attrs := attr.Value.Group()
+				attrs[0] = slog.String("msg", errorMessage(err))
+				attr.Value = slog.GroupValue(attrs...)
+			}
+		}
+	case slog.KindGroup:
+		groupAttrs := attr.Value.Group()
+		expanded := make([]slog.Attr, len(groupAttrs))
+		for i, groupAttr := range groupAttrs {
+			expanded[i] = expandSlogAttr(groupAttr)
+		}
+		attr.Value = slog.GroupValue(expanded...)
+	}
+	return attr
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestErrorLogValue(t *testing.T) {
+	logger.Info("err log value:", Details(New("test error detail")).LogValue().String())
+}
+
+func TestSlogHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	slogger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buffer, nil)))
+	slogger = slogger.With("attr", fmt.Errorf("test with: %w", New("test error detail")))
+	slogger.WithGroup("group").Error("test", "err", Wrap(errors.New("sub error"), "test error detail"),
+		slog.Group("sub", "err", fmt.Errorf("test: %w", New("sub error detail"))), "plain", errors.New("test"))
+	logger.Info("slog handler:", buffer.String())
+	if !strings.Contains(buffer.String(), `"sub":{"err":{"msg":"test: sub error detail"`) {
+		t.Error("error inside group was not expanded:", buffer.String())
+	}
+}
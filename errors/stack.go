@@ -0,0 +1,295 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// StackMode is the mode used to capture the stack trace of an ErrorDetail when it is created.
+// The package mode is set by SetStackMode, or by the environment variable GO_ERRORS_STACK_MODE with one of the names
+// "full", "none", "caller" or "all", and it can be overridden on a single call by passing a StackMode as the last
+// argument of the constructors without a format string, such as New or Wrap, which is not part of the message.
+// The arguments of the constructors with a format string, such as Newf, are all format operands, so a StackMode
+// passed to them is formatted by its name and doesn't change the mode.
+// Example usage:
+//
+//	err := New("cache miss", StackNone)
+//	fmt.Println(Details(err).GetDebugStack() == "") // Output: true
+type StackMode int
+
+const (
+	// StackFull captures the full stack trace of the current goroutine, it is the default mode.
+	StackFull StackMode = iota
+	// StackNone captures no stack trace, only the cause location is recorded.
+	StackNone
+	// StackCaller captures only the frame of the function that created the error.
+	StackCaller
+	// StackAllGoroutines captures the full stack trace of the current goroutine, as StackFull, and the debug stack
+	// of all goroutines, as printed by the Go runtime, which is returned by GetDebugStack, useful to diagnose
+	// deadlocks. It stops the world while the stacks are collected, so it must be used only on rare errors.
+	StackAllGoroutines
+)
+
+// maxStackDepth is the maximum number of program counters captured for a stack trace.
+const maxStackDepth = 64
+
+// regexStackArgs matches the argument values printed by the Go runtime after a function name of a stack trace.
+var regexStackArgs = regexp.MustCompile(`(?m)\((?:0x[0-9a-f]+\??|[{}, .])+\)$`)
+
+// regexStackOffset matches the program counter offset printed by the Go runtime after a file location of a stack
+// trace.
+var regexStackOffset = regexp.MustCompile(`(?m) \+0x[0-9a-f]+$`)
+
+// stackModeEnv is the name of the environment variable that sets the initial package StackMode.
+const stackModeEnv = "GO_ERRORS_STACK_MODE"
+
+// stackModeNames are the names of the StackMode values, used by String and ParseStackMode.
+var stackModeNames = map[StackMode]string{
+	StackFull:          "full",
+	StackNone:          "none",
+	StackCaller:        "caller",
+	StackAllGoroutines: "all",
+}
+
+// stackMode is the package StackMode, read on every error creation.
+var stackMode atomic.Int32
+
+func init() {
+	if mode, err := ParseStackMode(os.Getenv(stackModeEnv)); err == nil {
+		SetStackMode(mode)
+	}
+}
+
+// packagePath is the import path of this package, used to recognize the library's own frames.
+var packagePath = reflect.TypeOf(ErrorDetail{}).PkgPath()
+
+// Frame represents a single function invocation of a stack trace captured by an ErrorDetail.
+type Frame struct {
+	// Function is the fully qualified name of the function, for example "github.com/user/project/pkg.(*Type).Method".
+	Function string `json:"function"`
+	// File is the path of the source file containing the function, trimmed according to the package PathMode, see
+	// SetPathMode.
+	File string `json:"file"`
+	// Line is the line number in the source file.
+	Line int `json:"line"`
+	// Package is the import path of the package containing the function, for example "github.com/user/project/pkg".
+	Package string `json:"package"`
+	// PC is the program counter of the frame, it is zero when the frame was not captured in this process.
+	PC uintptr `json:"-"`
+	// Elided is the number of consecutive frames hidden by the StackFilter, when the frame is only the marker that
+	// replaces them, see SetStackFilter. It is zero for the frames of a function invocation.
+	Elided int `json:"elided,omitempty"`
+}
+
+// String is a method of the Frame struct that returns a formatted string representation of the frame.
+// It returns a string in the format "function\n\tfile:line", the same layout used by the Go runtime when
+// printing a goroutine stack, or "... N frames elided" for the marker of the frames hidden by the StackFilter.
+func (f Frame) String() string {
+	if f.Elided == 1 {
+		return "... 1 frame elided"
+	} else if f.Elided > 1 {
+		return "... " + strconv.Itoa(f.Elided) + " frames elided"
+	}
+	return f.Function + "\n\t" + f.File + ":" + strconv.Itoa(f.Line)
+}
+
+// String is a method of the StackMode type that returns the name of the mode, as accepted by ParseStackMode.
+func (m StackMode) String() string {
+	if name, ok := stackModeNames[m]; ok {
+		return name
+	}
+	return "StackMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// ParseStackMode is a function that returns the StackMode with the given name, one of "full", "none", "caller" or
+// "all", case-insensitive. It returns an error if the name is unknown.
+func ParseStackMode(name string) (StackMode, error) {
+	for mode, modeName := range stackModeNames {
+		if strings.EqualFold(strings.TrimSpace(name), modeName) {
+			return mode, nil
+		}
+	}
+	return StackFull, fmt.Errorf("unknown stack mode %q", name)
+}
+
+// SetStackMode is a function that sets the package StackMode, used by the constructors called without a StackMode
+// argument.
+// Example usage:
+//
+//	SetStackMode(StackCaller)
+func SetStackMode(mode StackMode) {
+	stackMode.Store(int32(mode))
+}
+
+// GetStackMode is a function that returns the package StackMode.
+func GetStackMode() StackMode {
+	return StackMode(stackMode.Load())
+}
+
+// stackModeArg is a function that returns the per-call StackMode passed as the last argument of `args`.
+// It returns false if the last argument is not a StackMode, or if there are no arguments.
+func stackModeArg(args []any) (StackMode, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	mode, ok := args[len(args)-1].(StackMode)
+	return mode, ok
+}
+
+// captureStack is a function that captures the stack of the calling goroutine in the StackMode passed as the last
+// argument of `args`, or in the package StackMode if there is none, see stackModeArg.
+// It takes in the number of frames to skip, where 0 identifies the function calling captureStack.
+// In the package StackMode, the full stack traces are limited by the StackSampling, see SetStackSampling.
+// It returns the captured program counters and, for StackAllGoroutines, the debug stack of all goroutines.
+func captureStack(skip int, args []any) ([]uintptr, string) {
+	mode, sampled := GetStackMode(), true
+	if argMode, ok := stackModeArg(args); ok {
+		mode, sampled = argMode, false
+	}
+	if sampled && stackSamplingEnabled.Load() && (mode == StackFull || mode == StackAllGoroutines) {
+		caller := callersDepth(skip+1, 1)
+		if len(caller) != 0 && !sampleStack(caller[0]) {
+			return caller, ""
+		}
+	}
+	switch mode {
+	case StackNone:
+		return nil, ""
+	case StackCaller:
+		return callersDepth(skip+1, 1), ""
+	case StackAllGoroutines:
+		return callers(skip + 1), allGoroutinesStack()
+	default:
+		return callers(skip + 1), ""
+	}
+}
+
+// allGoroutinesStack is a function that returns the stack traces of all goroutines, as printed by the Go runtime,
+// without the argument values and program counter offsets, see stripStackArgs, and with the source file paths
+// trimmed by the package PathMode, see trimStackPaths.
+func allGoroutinesStack() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return trimStackPaths(stripStackArgs(string(buf[:n])))
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// stripStackArgs is a function that removes from a stack trace text, as printed by the Go runtime, the hex values of
+// the function arguments, which may expose memory addresses and sensitive data, replacing them with "...", and the
+// program counter offsets "+0x..." after the file locations.
+// For example, "main.login(0xc000012345, 0x10)" results in "main.login(...)".
+func stripStackArgs(stack string) string {
+	stack = regexStackArgs.ReplaceAllString(stack, "(...)")
+	return regexStackOffset.ReplaceAllString(stack, "")
+}
+
+// trimStackPaths is a function that trims, by the package PathMode, the source file paths of a stack trace text, as
+// printed by the Go runtime, where each file location line, indented by a tab, follows the line of its function,
+// either "function(...)" or "created by function in goroutine N".
+// For example, "main.main(...)\n\t/home/user/project/main.go:5" results in "main.main(...)\n\tproject/main.go:5".
+func trimStackPaths(stack string) string {
+	lines := strings.Split(stack, "\n")
+	for i := 1; i < len(lines); i++ {
+		location, ok := strings.CutPrefix(lines[i], "\t")
+		index := strings.LastIndex(location, ":")
+		if !ok || index < 0 {
+			continue
+		}
+		lines[i] = "\t" + trimPath(location[:index], stackLineFunction(lines[i-1])) + location[index:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stackLineFunction is a function that returns the function name of a function line of a stack trace text, either
+// as printed by the Go runtime, "function(...)" or "created by function in goroutine N", or as rendered by
+// renderFrames, without the arguments.
+func stackLineFunction(line string) string {
+	if created, ok := strings.CutPrefix(line, "created by "); ok {
+		function, _, _ := strings.Cut(created, " in goroutine ")
+		return function
+	} else if index := strings.LastIndex(line, "("); index > 0 && strings.HasSuffix(line, ")") {
+		return line[:index]
+	}
+	return line
+}
+
+// callers is a function that captures the program counters of the calling goroutine's stack.
+// It takes in the number of frames to skip, where 0 identifies the function calling callers and 1 its caller.
+// It returns the captured program counters, which are resolved lazily by resolveFrames.
+func callers(skip int) []uintptr {
+	return callersDepth(skip+1, maxStackDepth)
+}
+
+// callersDepth is a function that captures at most `depth` program counters of the calling goroutine's stack, with
+// the same `skip` semantics of callers.
+func callersDepth(skip, depth int) []uintptr {
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// resolveFrames is a function that resolves the given program counters into frames using runtime.CallersFrames.
+// The leading frames that belong to this library, and the runtime.goexit frame that ends every goroutine, are
+// trimmed from the result.
+func resolveFrames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var frames []Frame
+	callersFrames := runtime.CallersFrames(pcs)
+	for {
+		runtimeFrame, more := callersFrames.Next()
+		frame := Frame{
+			Function: runtimeFrame.Function,
+			File:     trimPath(runtimeFrame.File, runtimeFrame.Function),
+			Line:     runtimeFrame.Line,
+			Package:  packageName(runtimeFrame.Function),
+			PC:       runtimeFrame.PC,
+		}
+		if (len(frames) != 0 || !isLibraryFrame(frame)) && frame.Function != "runtime.goexit" {
+			frames = append(frames, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// renderFrames is a function that builds the text representation of the given frames, one frame per two lines,
+// in the same layout used by the Go runtime when printing a goroutine stack.
+func renderFrames(frames []Frame) string {
+	var builder strings.Builder
+	for _, frame := range frames {
+		builder.WriteString(frame.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// isLibraryFrame is a function that checks if the given frame belongs to the source files of this library.
+// Frames from test files of the package are not considered library frames.
+func isLibraryFrame(frame Frame) bool {
+	return frame.Package == packagePath && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// packageName is a function that extracts the package import path from a fully qualified function name.
+// For example, "github.com/user/project/pkg.(*Type).Method" results in "github.com/user/project/pkg".
+func packageName(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[lastSlash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:lastSlash+1+dot]
+}
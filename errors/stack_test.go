@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"encoding/json"
+	"github.com/GabrielHCataldo/go-logger/logger"
+	"strings"
+	"testing"
+)
+
+func TestErrorStackTrace(t *testing.T) {
+	stackTrace := New("test error detail").(*ErrorDetail).StackTrace()
+	for _, frame := range stackTrace {
+		logger.Info("err frame:", frame.Function, frame.Package, frame.File, frame.Line)
+	}
+	if len(stackTrace) == 0 || !strings.HasSuffix(stackTrace[0].Function, ".TestErrorStackTrace") {
+		t.Error("first frame should be the caller of New, got:", stackTrace)
+	}
+	logger.Info("err frames:", Details(New("test")).StackTrace())
+}
+
+func TestErrorStackTraceSkipCaller(t *testing.T) {
+	stackTrace := NewSkipCaller(2, "test error detail").(*ErrorDetail).StackTrace()
+	if len(stackTrace) == 0 || stackTrace[0].Function != "testing.tRunner" {
+		t.Error("first frame should be the caller of the test, got:", stackTrace)
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	frame := Frame{Function: "main.main", File: "/path/to/main.go", Line: 10, Package: "main"}
+	logger.Info("frame:", frame.String())
+}
+
+func TestPackageName(t *testing.T) {
+	logger.Info("package name:", packageName("github.com/user/project/pkg.(*Type).Method"))
+	logger.Info("package name:", packageName("main.main"))
+	logger.Info("package name:", packageName("main"))
+}
+
+func TestStackMode(t *testing.T) {
+	errNone := New("test error detail", StackNone).(*ErrorDetail)
+	if len(errNone.StackTrace()) != 0 || errNone.GetDebugStack() != "" || errNone.GetMessage() != "test error detail" {
+		t.Error("no stack should be captured:", errNone.StackTrace())
+	}
+	logger.Info("err none:", errNone)
+	errNone.PrintStackTrace()
+	errCaller := New("test error detail", StackCaller).(*ErrorDetail)
+	if len(errCaller.StackTrace()) != 1 || errCaller.GetMessage() != "test error detail" {
+		t.Error("only the caller should be captured:", errCaller.StackTrace())
+	}
+	logger.Info("err caller:", errCaller)
+	errOperand := Newf("%s mode", StackCaller).(*ErrorDetail)
+	if len(errOperand.StackTrace()) <= 1 || errOperand.GetMessage() != "caller mode" {
+		t.Error("stack mode format operand should be kept:", errOperand.GetMessage())
+	}
+	errAll := Wrap(New("test"), "test error detail", StackAllGoroutines).(*ErrorDetail)
+	if !strings.HasPrefix(errAll.GetDebugStack(), "goroutine ") || len(errAll.StackTrace()) == 0 {
+		t.Error("all goroutines should be captured:", errAll.GetDebugStack())
+	}
+	data, _ := json.Marshal(errAll)
+	parsed, err := ParseJSON(data)
+	if err != nil || parsed.GetDebugStack() != errAll.GetDebugStack() {
+		t.Error("all goroutines stack should be encoded in json:", err)
+	}
+}
+
+func TestSetStackMode(t *testing.T) {
+	SetStackMode(StackNone)
+	defer SetStackMode(StackFull)
+	if GetStackMode() != StackNone || len(Details(New("test")).StackTrace()) != 0 {
+		t.Error("package stack mode should be used")
+	}
+	if len(Details(New("test", StackFull)).StackTrace()) == 0 {
+		t.Error("stack mode argument should override the package mode")
+	}
+}
+
+func TestParseStackMode(t *testing.T) {
+	for _, mode := range []StackMode{StackFull, StackNone, StackCaller, StackAllGoroutines} {
+		parsed, err := ParseStackMode(strings.ToUpper(mode.String()))
+		if err != nil || parsed != mode {
+			t.Error("stack mode should be parsed:", mode, parsed, err)
+		}
+	}
+	_, err := ParseStackMode("invalid")
+	logger.Info("parse stack mode:", err, StackMode(10))
+}